@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Map IPv4 addresses to MAC addresses from the ARP table and the hardware
+// addresses of local interfaces, addresses without an entry are left out
+func lookupMACs(addresses []string) map[string]net.HardwareAddr {
+	macs := make(map[string]net.HardwareAddr)
+
+	// Addresses of this host are not in the ARP table
+	ifaces, _ := net.Interfaces()
+	for _, iface := range ifaces {
+		if len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		addrs, _ := iface.Addrs()
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok {
+				macs[ipnet.IP.String()] = iface.HardwareAddr
+			}
+		}
+	}
+
+	if "linux" == runtime.GOOS {
+		readProcARP(macs)
+	} else {
+		for _, address := range addresses {
+			if _, ok := macs[address]; !ok {
+				readARPCommand(macs, address)
+			}
+		}
+	}
+	return macs
+}
+
+// Read complete entries of /proc/net/arp
+func readProcARP(macs map[string]net.HardwareAddr) {
+	f, err := os.Open("/proc/net/arp")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		// IP address, HW type, Flags, HW address, Mask, Device
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || "0x0" == fields[2] {
+			continue
+		}
+		if mac, err := net.ParseMAC(fields[3]); err == nil && !isZeroMAC(mac) {
+			macs[fields[0]] = mac
+		}
+	}
+}
+
+// Parse "? (192.168.1.1) at a:b:c:d:e:f on en0 ..." as printed by arp -n on
+// macOS and the BSDs, which do not zero pad the octets
+func readARPCommand(macs map[string]net.HardwareAddr, address string) {
+	out, err := exec.Command("arp", "-n", address).Output()
+	if err != nil {
+		return
+	}
+	fields := strings.Fields(string(out))
+	for i, field := range fields {
+		if "at" != field || i+1 == len(fields) {
+			continue
+		}
+		octets := strings.Split(fields[i+1], ":")
+		for j, octet := range octets {
+			if len(octet) == 1 {
+				octets[j] = "0" + octet
+			}
+		}
+		if mac, err := net.ParseMAC(strings.Join(octets, ":")); err == nil && !isZeroMAC(mac) {
+			macs[address] = mac
+		}
+		return
+	}
+}
+
+func isZeroMAC(mac net.HardwareAddr) bool {
+	for _, b := range mac {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
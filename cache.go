@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// Load results saved by writeCache if the file is younger than ttl,
+// keeping only services of the given types
+func readCache(path string, ttl time.Duration, types []string) ([]Service, bool) {
+	if ttl <= 0 {
+		return nil, false
+	}
+	fi, err := os.Stat(path)
+	if err != nil || time.Since(fi.ModTime()) > ttl {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cached []Service
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false
+	}
+
+	wanted := make(map[string]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+	found := make([]Service, 0, len(cached))
+	for _, s := range cached {
+		if wanted[s.ServiceType] {
+			s.Cached = true
+			found = append(found, s)
+		}
+	}
+	return found, true
+}
+
+func writeCache(path string, discovered []Service) error {
+	data, err := json.Marshal(discovered)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
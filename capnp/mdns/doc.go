@@ -0,0 +1,4 @@
+// Package mdns holds the structs capnpc-go generates for schema.capnp
+package mdns
+
+//go:generate capnp compile -I.. -ogo:. --src-prefix=../.. ../../schema.capnp
@@ -0,0 +1,24 @@
+# @summary A service discovered by mdns-discover
+#
+# Declarations are generated with
+#
+#   mdns-discover --output=puppet-manifest > mdns_services.pp
+#
+# This defined type only records the parameters, wrap or replace it
+# to act on discovered services.
+#
+# @param hostname
+#   Hostname the service was discovered on
+# @param address
+#   IPv4 or IPv6 address of the host
+# @param port
+#   Port of the service
+# @param service_type
+#   DNS-SD service type, e.g. _http._tcp
+define mdns_service (
+  String $hostname,
+  String $address,
+  Integer $port,
+  String $service_type,
+) {
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grandcat/zeroconf"
+)
+
+// Default time to browse for each service type
+const discoverTimeout = time.Second * 15
+
+// Default number of service types browsed at the same time
+const defaultMaxConcurrent = 1
+
+// Default number of attempts to create a resolver and the delay
+// the jittered backoff between attempts starts from
+const (
+	defaultResolverAttempts = 3
+	resolverRetryDelay      = time.Millisecond * 500
+)
+
+// DiscoverConfig holds the settings of a discovery run
+type DiscoverConfig struct {
+	ServiceType    string
+	OutputFields   []string
+	PrintResults   bool
+	Timeout        time.Duration
+	MaxConcurrent  int
+	MaxAttempts    int
+	Debug          bool
+	Interface      string
+	IPVersion      string
+	SubnetFilter   *net.IPNet
+	HostnameFilter string
+	TxtFilter      string
+	NoTXT          bool
+	Domain         string
+	MaxResults     int
+}
+
+// Resolver options selecting the interface and IP version
+func (cfg DiscoverConfig) resolverOptions() ([]zeroconf.ClientOption, error) {
+	var opts []zeroconf.ClientOption
+	if "" != cfg.Interface {
+		iface, err := net.InterfaceByName(cfg.Interface)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, zeroconf.SelectIfaces([]net.Interface{*iface}))
+	}
+	switch cfg.IPVersion {
+	case "4":
+		opts = append(opts, zeroconf.SelectIPTraffic(zeroconf.IPv4))
+	case "6":
+		opts = append(opts, zeroconf.SelectIPTraffic(zeroconf.IPv6))
+	}
+	return opts, nil
+}
+
+// Report whether a service passes the subnet, hostname and TXT filters
+func (cfg DiscoverConfig) accept(s Service) bool {
+	if cfg.SubnetFilter != nil {
+		ip := net.ParseIP(s.Address)
+		if ip == nil || !cfg.SubnetFilter.Contains(ip) {
+			return false
+		}
+	}
+	if "" != cfg.HostnameFilter {
+		match, err := path.Match(strings.ToLower(cfg.HostnameFilter), strings.ToLower(shortHostname(s.Hostname)))
+		if err != nil || !match {
+			return false
+		}
+	}
+	if "" != cfg.TxtFilter {
+		key, value, hasValue := strings.Cut(cfg.TxtFilter, "=")
+		v, ok := s.TxtMap[key]
+		if !ok || (hasValue && v != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Create a resolver, retrying up to maxAttempts times since creation
+// fails while the network interface is briefly unavailable
+func newResolverWithRetry(ctx context.Context, maxAttempts int, baseDelay time.Duration,
+	opts ...zeroconf.ClientOption) (*zeroconf.Resolver, error) {
+	if maxAttempts < 1 {
+		maxAttempts = defaultResolverAttempts
+	}
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			// Sleep between half and the full exponential delay
+			delay := baseDelay << (attempt - 1)
+			delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		var resolver *zeroconf.Resolver
+		resolver, err = zeroconf.NewResolver(opts...)
+		if err == nil {
+			return resolver, nil
+		}
+	}
+	return nil, err
+}
+
+// Browse for cfg.ServiceType until the timeout expires or found returns false
+func browse(cfg DiscoverConfig, timeout time.Duration, found func([]Service) bool) {
+	opts, err := cfg.resolverOptions()
+	if err != nil {
+		log.Fatalln("Failed to select interface:", err.Error())
+	}
+	resolver, err := newResolverWithRetry(context.Background(), cfg.MaxAttempts, resolverRetryDelay, opts...)
+	if err != nil {
+		log.Fatalln("Failed to initialize resolver:", err.Error())
+	}
+
+	domain := cfg.Domain
+	if "" == domain {
+		domain = "local."
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	entries := make(chan *zeroconf.ServiceEntry)
+	go func(results <-chan *zeroconf.ServiceEntry) {
+		for entry := range results {
+			if cfg.Debug {
+				fmt.Fprintf(os.Stderr, "debug: %s %s %s port=%d ipv4=%v ipv6=%v txt=%v\n", cfg.ServiceType,
+					entry.Instance, entry.HostName, entry.Port, entry.AddrIPv4, entry.AddrIPv6, entry.Text)
+			}
+			if cfg.NoTXT {
+				entry.Text = nil
+			}
+
+			var accepted []Service
+			for _, s := range newServices(cfg.ServiceType, entry) {
+				if cfg.accept(s) {
+					accepted = append(accepted, s)
+				}
+			}
+			if !found(accepted) {
+				cancel()
+			}
+		}
+		close(done)
+	}(entries)
+
+	err = resolver.Browse(ctx, cfg.ServiceType, domain, entries)
+	if err != nil {
+		log.Fatalln("Failed to browse:", err.Error())
+	}
+
+	<-ctx.Done()
+	<-done
+}
+
+// Browse used by discover, replaced in tests to run without a network
+var browseServices = browse
+
+// Discover services of cfg.ServiceType, browsing stops early
+// once cfg.MaxResults services are found
+func discover(cfg DiscoverConfig) []Service {
+	var found []Service
+	browseServices(cfg, cfg.Timeout, func(entry []Service) bool {
+		found = append(found, entry...)
+		if cfg.MaxResults > 0 && len(found) >= cfg.MaxResults {
+			found = found[:cfg.MaxResults]
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// Discover all service types, at most cfg.MaxConcurrent at a time.
+// With cfg.PrintResults handle is called with the results of each service
+// type as it finishes, the returned services are in the order of names
+func discoverAll(names []string, cfg DiscoverConfig, p *progress, handle func([]Service)) []Service {
+	limit := cfg.MaxConcurrent
+	if limit < 1 {
+		limit = defaultMaxConcurrent
+	}
+
+	results := make([][]Service, len(names))
+	sem := make(chan struct{}, limit)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, name := range names {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			c := cfg
+			c.ServiceType = name
+			p.begin()
+			found := discover(c)
+			p.end(len(found))
+
+			mu.Lock()
+			results[i] = found
+			if cfg.PrintResults && handle != nil {
+				handle(found)
+			}
+			mu.Unlock()
+		}(i, name)
+	}
+	wg.Wait()
+
+	// Start with an empty slice so modes marshaling it emit [] rather than null
+	discovered := make([]Service, 0)
+	for _, found := range results {
+		discovered = append(discovered, found...)
+	}
+	return discovered
+}
+
+// Browse for cfg.ServiceType repeatedly until a service, with the given
+// hostname if not empty, is found or maxRuntime is reached, zero means no limit
+func waitFor(cfg DiscoverConfig, hostname string, maxRuntime time.Duration) ([]Service, bool) {
+	var deadline time.Time
+	if maxRuntime > 0 {
+		deadline = time.Now().Add(maxRuntime)
+	}
+
+	for {
+		attempt := cfg.Timeout
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return nil, false
+			}
+			if remaining < attempt {
+				attempt = remaining
+			}
+		}
+
+		var matched []Service
+		browse(cfg, attempt, func(entry []Service) bool {
+			for _, s := range entry {
+				if "" == hostname || strings.EqualFold(shortHostname(s.Hostname), shortHostname(hostname)) {
+					matched = append(matched, s)
+				}
+			}
+			return len(matched) == 0
+		})
+		if len(matched) > 0 {
+			return matched, true
+		}
+	}
+}
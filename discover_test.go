@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Replace browse for the duration of the test
+func stubBrowse(t *testing.T, stub func(DiscoverConfig, time.Duration, func([]Service) bool)) {
+	browseServices = stub
+	t.Cleanup(func() { browseServices = browse })
+}
+
+// Browse stub reporting the given services of each type in one go
+func browseResults(results map[string][]Service) func(DiscoverConfig, time.Duration, func([]Service) bool) {
+	return func(cfg DiscoverConfig, timeout time.Duration, found func([]Service) bool) {
+		found(results[cfg.ServiceType])
+	}
+}
+
+func TestDiscoverAllConcurrentConfigs(t *testing.T) {
+	var mu sync.Mutex
+	inFlight := make(map[int]int)
+	maxInFlight := make(map[int]int)
+
+	// Report one service per type after a short delay, tracking the
+	// browses in flight per MaxConcurrent of the calling config
+	stubBrowse(t, func(cfg DiscoverConfig, timeout time.Duration, found func([]Service) bool) {
+		mu.Lock()
+		inFlight[cfg.MaxConcurrent]++
+		if inFlight[cfg.MaxConcurrent] > maxInFlight[cfg.MaxConcurrent] {
+			maxInFlight[cfg.MaxConcurrent] = inFlight[cfg.MaxConcurrent]
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		found([]Service{{ServiceType: cfg.ServiceType, Instance: fmt.Sprint(cfg.MaxConcurrent)}})
+
+		mu.Lock()
+		inFlight[cfg.MaxConcurrent]--
+		mu.Unlock()
+	})
+
+	names := []string{"_a._tcp", "_b._tcp", "_c._tcp", "_d._tcp", "_e._tcp", "_f._tcp"}
+	limits := []int{1, 3}
+	results := make([][]Service, len(limits))
+	var wg sync.WaitGroup
+	for i, limit := range limits {
+		wg.Add(1)
+		go func(i int, cfg DiscoverConfig) {
+			defer wg.Done()
+			results[i] = discoverAll(names, cfg, nil, nil)
+		}(i, DiscoverConfig{MaxConcurrent: limit})
+	}
+	wg.Wait()
+
+	for i, limit := range limits {
+		if maxInFlight[limit] != limit {
+			t.Errorf("MaxConcurrent %d: got %d browses in flight", limit, maxInFlight[limit])
+		}
+		if len(results[i]) != len(names) {
+			t.Fatalf("MaxConcurrent %d: got %d services, want %d", limit, len(results[i]), len(names))
+		}
+		for j, s := range results[i] {
+			if s.ServiceType != names[j] || s.Instance != fmt.Sprint(limit) {
+				t.Errorf("MaxConcurrent %d: service %d is %+v", limit, j, s)
+			}
+		}
+	}
+}
+
+func TestDiscoverAllNoResultsMarshalsEmpty(t *testing.T) {
+	stubBrowse(t, func(cfg DiscoverConfig, timeout time.Duration, found func([]Service) bool) {})
+
+	discovered := discoverAll([]string{"_http._tcp", "_ssh._tcp"}, DiscoverConfig{}, nil, nil)
+	for name, cfg := range map[string]OutputConfig{
+		"default":        {},
+		"--ip-version=4": {IPVersion: "4"},
+		"--base64-txt":   {Base64TXT: true},
+		"--mask-field":   {MaskFields: []string{"address"}},
+	} {
+		for mode, want := range map[OutputMode]string{
+			OutputJSON:        "[]\n",
+			OutputJSONPointer: "\t[]\n",
+			OutputAnsibleVars: "mdns_services: []\n",
+			OutputMessagePack: "\x90",
+			OutputCBOR:        "\x80",
+		} {
+			var buf bytes.Buffer
+			err := writeOutput(&buf, mode, prepareServices(discovered, cfg), cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != want {
+				t.Errorf("%s with %s: got %q, want %q", mode, name, buf.String(), want)
+			}
+		}
+	}
+}
+
+func TestDiscoverAllPrintResults(t *testing.T) {
+	stubBrowse(t, browseResults(map[string][]Service{
+		"_http._tcp": {{ServiceType: "_http._tcp", Hostname: "web.local."}},
+		"_ssh._tcp":  {{ServiceType: "_ssh._tcp", Hostname: "box.local."}},
+	}))
+	names := []string{"_http._tcp", "_ssh._tcp"}
+
+	for _, printResults := range []bool{false, true} {
+		var handled []Service
+		discovered := discoverAll(names, DiscoverConfig{PrintResults: printResults}, nil, func(found []Service) {
+			handled = append(handled, found...)
+		})
+		if len(discovered) != 2 {
+			t.Errorf("PrintResults %v: got %d services, want 2", printResults, len(discovered))
+		}
+		want := 0
+		if printResults {
+			want = 2
+		}
+		if len(handled) != want {
+			t.Errorf("PrintResults %v: handled %d services, want %d", printResults, len(handled), want)
+		}
+	}
+}
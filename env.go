@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Environment variables read by mdns-discover
+var envVars = []string{
+	"MDNS_SERVICE_FILTER",
+	"MDNS_OUTPUT",
+	"MDNS_FIELD_SEPARATOR",
+}
+
+// Print the recognized environment variables and their values,
+// values of tokens and secrets are masked
+func printEnv(w io.Writer) {
+	for _, name := range envVars {
+		value, ok := os.LookupEnv(name)
+		switch {
+		case !ok:
+			value = "(not set)"
+		case strings.Contains(name, "TOKEN") || strings.Contains(name, "SECRET"):
+			value = "***"
+		default:
+			value = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(w, "%s=%s\n", name, value)
+	}
+}
+
+// Flag value collecting every occurrence of a repeatable flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
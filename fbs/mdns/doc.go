@@ -0,0 +1,5 @@
+// Package mdns holds the accessors and builders flatc --go generates
+// for the tables of fbs/Service.fbs
+package mdns
+
+//go:generate flatc --go -o .. ../Service.fbs
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// Read a file of known services, one buildKey per line,
+// empty lines and lines starting with # are ignored
+func readKnownServices(path string) (map[string]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	known := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if "" == line || strings.HasPrefix(line, "#") {
+			continue
+		}
+		known[line] = true
+	}
+	return known, scanner.Err()
+}
@@ -1,69 +1,498 @@
 package main
 
 import (
-	"context"
-    "fmt"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"text/template"
 	"time"
-
-	"github.com/grandcat/zeroconf"
 )
 
 //go:generate go run gen/gen_services.go
 
-func discover(name string) {
-	resolver, err := zeroconf.NewResolver(nil)
+// Exit codes
+const (
+	exitOK       = 0
+	exitErr      = 1
+	exitUsage    = 2
+	exitNotFound = 7
+)
+
+func help(name string, version string) {
+	fmt.Printf("\n%s version: %s\n\n", name, version)
+	fmt.Printf(" Usage:\n\n")
+	fmt.Printf("  mdns-discover                             - Show all discovered devices\n\n")
+	fmt.Printf("  MDNS_SERVICE_FILTER=\"_workstation._tcp\" \\\n")
+	fmt.Printf("  mdns-discover                             - Show filtered devices\n\n")
+	fmt.Printf("  MDNS_OUTPUT=\"influxdb\" mdns-discover      - Select the output format\n\n")
+	fmt.Printf("  mdns-discover --field-separator=\",\"       - Separate text output fields\n\n")
+	fmt.Printf("  mdns-discover --field-format=port=%%05d    - Format text output fields with fmt verbs\n\n")
+	fmt.Printf("  mdns-discover --output-file=<path>        - Write output to a file\n\n")
+	fmt.Printf("  mdns-discover --output=json --compress    - Compress json, csv or tsv output with gzip\n\n")
+	fmt.Printf("  mdns-discover --output=json --base64-txt  - Encode TXT records with Base64\n\n")
+	fmt.Printf("  mdns-discover --mask-field=address        - Replace field values with ***, repeatable\n\n")
+	fmt.Printf("  mdns-discover --ip-version=<4|6|all>      - Only show IPv4 or IPv6 addresses, default 4\n\n")
+	fmt.Printf("  mdns-discover --timeout=15s               - Browse each service type for a duration\n\n")
+	fmt.Printf("  mdns-discover --concurrency=4             - Browse service types in parallel\n\n")
+	fmt.Printf("  mdns-discover --wait-for=_http._tcp \\\n")
+	fmt.Printf("  [--wait-for-hostname=<host>] \\\n")
+	fmt.Printf("  [--max-runtime=<duration>]                - Wait until a service appears, exit 7 on timeout\n\n")
+	fmt.Printf("  mdns-discover --progress                  - Show progress on stderr\n\n")
+	fmt.Printf("  mdns-discover --cache-file=<path> \\\n")
+	fmt.Printf("  [--cache-ttl=<duration>]                  - Reuse results of a previous run\n\n")
+	fmt.Printf("  mdns-discover --print-env                 - Print environment variables to stderr\n\n")
+	fmt.Printf("  mdns-discover --dry-run                   - Validate configuration and exit\n\n")
+	fmt.Printf("  mdns-discover --output=netbox-json        - Show devices as NetBox import JSON\n\n")
+	fmt.Printf("  mdns-discover --output=netbox-json \\\n")
+	fmt.Printf("  --netbox-url=<url> --netbox-token=<token> - Import devices into NetBox\n\n")
+	fmt.Printf("  mdns-discover --output=syslog \\\n")
+	fmt.Printf("  [--syslog-addr=<host:port>]               - Send devices to syslog\n\n")
+	fmt.Printf("  mdns-discover --output=influxdb           - Show devices as InfluxDB line protocol\n\n")
+	fmt.Printf("  mdns-discover --output=haproxy            - Show devices as HAProxy backends\n\n")
+	fmt.Printf("  mdns-discover --output=nginx \\\n")
+	fmt.Printf("  [--nginx-weight-key=<txtkey>]             - Show devices as nginx upstreams\n\n")
+	fmt.Printf("  mdns-discover --output=etcd-json          - Show devices as etcd key-value JSON\n\n")
+	fmt.Printf("  mdns-discover --output=etcd-json \\\n")
+	fmt.Printf("  --etcd-endpoints=<url> [--etcd-ttl=<ttl>] - Write devices to etcd\n\n")
+	fmt.Printf("  mdns-discover --output=zabbix             - Show devices as Zabbix LLD JSON\n\n")
+	fmt.Printf("  mdns-discover --output=kubernetes         - Show devices as Kubernetes EndpointSlices\n\n")
+	fmt.Printf("  mdns-discover --output=slack \\\n")
+	fmt.Printf("  --slack-webhook=<url> \\\n")
+	fmt.Printf("  [--known-services-file=<path>]            - Post devices to Slack\n\n")
+	fmt.Printf("  mdns-discover --output=opnsense-xml       - Show devices as OPNsense/pfSense XML\n\n")
+	fmt.Printf("  mdns-discover --output=vault              - Show devices as vault kv put commands\n\n")
+	fmt.Printf("  mdns-discover --output=vault \\\n")
+	fmt.Printf("  --vault-addr=<url> --vault-token=<token> \\\n")
+	fmt.Printf("  [--vault-mount=secret]                    - Write devices to Vault\n\n")
+	fmt.Printf("  mdns-discover --output=terraform-hcl      - Show devices as Terraform locals\n\n")
+	fmt.Printf("  mdns-discover --output=bind-zone \\\n")
+	fmt.Printf("  --zone-nameserver=<name> \\\n")
+	fmt.Printf("  [--zone-nameserver-address=<address>] \\\n")
+	fmt.Printf("  [--zone-origin=local.]                    - Show devices as BIND zone file\n\n")
+	fmt.Printf("  mdns-discover --output=json               - Show devices as JSON\n\n")
+	fmt.Printf("  mdns-discover --output=dnsmasq            - Show devices as dnsmasq address directives\n\n")
+	fmt.Printf("  mdns-discover --output=coredns-hosts      - Show devices as CoreDNS hosts block\n\n")
+	fmt.Printf("  mdns-discover --output=caddyfile          - Show HTTP devices as Caddyfile\n\n")
+	fmt.Printf("  mdns-discover --output=csv \\\n")
+	fmt.Printf("  [--column-name=hostname=Host,...]         - Show devices as CSV\n\n")
+	fmt.Printf("  mdns-discover --output=tsv                - Show devices as TSV\n\n")
+	fmt.Printf("  mdns-discover --output=docker-dns         - Show devices as Docker Compose extra_hosts\n\n")
+	fmt.Printf("  mdns-discover --output=ssdp               - Show devices as SSDP NOTIFY messages\n\n")
+	fmt.Printf("  mdns-discover --output=prometheus-gateway \\\n")
+	fmt.Printf("  --pushgateway-url=<url> \\\n")
+	fmt.Printf("  [--pushgateway-job=mdns-discover]         - Push devices to a Pushgateway\n\n")
+	fmt.Printf("  mdns-discover --output=rss                - Show devices as RSS 2.0 feed\n\n")
+	fmt.Printf("  mdns-discover --output=hiera              - Show devices as Puppet Hiera YAML\n\n")
+	fmt.Printf("  mdns-discover --output=hosts-allow \\\n")
+	fmt.Printf("  [--tcp-daemon=<name>]                     - Show devices as hosts.allow rules\n\n")
+	fmt.Printf("  mdns-discover --output=frr \\\n")
+	fmt.Printf("  [--frr-asn=<n>]                           - Show devices as FRR BGP neighbors\n\n")
+	fmt.Printf("  mdns-discover --output=salt               - Show devices as SaltStack grains YAML\n\n")
+	fmt.Printf("  mdns-discover --output=ansible-vars       - Show devices as Ansible variables YAML\n\n")
+	fmt.Printf("  mdns-discover --output=chef               - Show devices as Chef node attributes\n\n")
+	fmt.Printf("  mdns-discover --output=checkmk-local      - Show devices as Checkmk local checks\n\n")
+	fmt.Printf("  mdns-discover --output=icinga2            - Show devices as Icinga2 configuration\n\n")
+	fmt.Printf("  mdns-discover --output=traefik            - Show devices as Traefik dynamic configuration\n\n")
+	fmt.Printf("  mdns-discover --output=puppet-manifest    - Show devices as Puppet resources\n\n")
+	fmt.Printf("  mdns-discover --output=telegraf           - Show devices as Telegraf inputs\n\n")
+	fmt.Printf("  mdns-discover --output=loki \\\n")
+	fmt.Printf("  [--loki-url=<url>]                        - Push devices to Grafana Loki\n\n")
+	fmt.Printf("  mdns-discover --output=coredns-forward    - Show DNS resolvers as CoreDNS forward block\n\n")
+	fmt.Printf("  mdns-discover --output=powershell         - Show devices as PowerShell objects\n\n")
+	fmt.Printf("  mdns-discover --output=ruby               - Show devices as Ruby hashes\n\n")
+	fmt.Printf("  mdns-discover --output=python \\\n")
+	fmt.Printf("  [--python-module]                         - Show devices as Python list of dicts\n\n")
+	fmt.Printf("  mdns-discover --output=ios-shortcuts      - Show devices as Apple Shortcuts dictionary\n\n")
+	fmt.Printf("  mdns-discover --output=weave-scope        - Show devices as Weave Scope plugin report\n\n")
+	fmt.Printf("  mdns-discover --output=nagios-config      - Show devices as Nagios object configuration\n\n")
+	fmt.Printf("  mdns-discover --output=opentsdb-telnet \\\n")
+	fmt.Printf("  [--opentsdb-addr=<host:port>]             - Stream devices to OpenTSDB\n\n")
+	fmt.Printf("  mdns-discover --output=coredns-etcd \\\n")
+	fmt.Printf("  [--zone-origin=local.]                    - Show devices as etcdctl SkyDNS commands\n\n")
+	fmt.Printf("  mdns-discover --output=coredns-etcd \\\n")
+	fmt.Printf("  --etcd-endpoints=<url> [--etcd-ttl=<ttl>] - Write SkyDNS records to etcd\n\n")
+	fmt.Printf("  mdns-discover --output=json-ld            - Show devices as JSON-LD graph\n\n")
+	fmt.Printf("  mdns-discover --output=gelf \\\n")
+	fmt.Printf("  [--gelf-addr=<host:port>]                 - Send devices to Graylog via GELF UDP\n\n")
+	fmt.Printf("  mdns-discover --output=socket-io          - Show devices as Socket.IO mdns_service events\n\n")
+	fmt.Printf("  mdns-discover --output=sflow \\\n")
+	fmt.Printf("  [--sflow-collector=<host:port>]           - Send devices to an sFlow collector\n\n")
+	fmt.Printf("  mdns-discover --output=json-pointer       - Show JSON Pointers and values of json output\n\n")
+	fmt.Printf("  mdns-discover --output=avro               - Show devices as Avro object container file\n\n")
+	fmt.Printf("  mdns-discover --output=parquet \\\n")
+	fmt.Printf("  --output-file=<path>                      - Write devices to a Parquet file\n\n")
+	fmt.Printf("  mdns-discover --output=messagepack        - Show devices as MessagePack\n\n")
+	fmt.Printf("  mdns-discover --output=cbor               - Show devices as CBOR\n\n")
+	fmt.Printf("  mdns-discover --output=protobuf           - Show devices as length-prefixed protobuf\n\n")
+	fmt.Printf("  mdns-discover --output=protobuf-json      - Show devices as protobuf JSON\n\n")
+	fmt.Printf("  mdns-discover --output=flatbuffers        - Show devices as FlatBuffers\n\n")
+	fmt.Printf("  mdns-discover --output=cap-n-proto \\\n")
+	fmt.Printf("  [--capnp-text]                            - Show devices as packed Cap'n Proto message\n\n")
+	fmt.Printf("  mdns-discover --output=json-stream \\\n")
+	fmt.Printf("  [--json-chunk-size=<n>]                   - Stream devices as NDJSON, n records per line\n\n")
+	fmt.Printf("  mdns-discover --output=json-template \\\n")
+	fmt.Printf("  --json-template=<template>                - Show devices with a Go template\n\n")
+	fmt.Printf("  mdns-discover --output=dns-zone-dynamic   - Show devices as nsupdate commands\n\n")
+	fmt.Printf("  mdns-discover --output=dns-zone-dynamic \\\n")
+	fmt.Printf("  --nsupdate-server=<ip> \\\n")
+	fmt.Printf("  [--nsupdate-key=[alg:]name:secret]        - Update DNS with nsupdate\n\n")
+	fmt.Printf("  mdns-discover --output=wireguard          - Show WireGuard peers\n\n")
+	fmt.Printf("  mdns-discover --output=unbound            - Show devices as Unbound local-data\n\n")
+	fmt.Printf("  mdns-discover --output=systemd-resolved   - Show DNS resolvers for systemd-resolved\n\n")
+	fmt.Printf("  mdns-discover --output=arista-eos         - Show static ARP entries for Arista EOS\n\n")
+	fmt.Printf("  mdns-discover --output=cisco-ios          - Show devices as Cisco IOS ip host entries\n\n")
+	fmt.Printf("  mdns-discover --output=junos              - Show devices as Junos static host mappings\n\n")
+	fmt.Printf("  mdns-discover --output=panos              - Show devices as PAN-OS address objects\n\n")
+	fmt.Printf("  mdns-discover --output=panos \\\n")
+	fmt.Printf("  --panos-api=<url> --panos-key=<key>       - Add address objects to PAN-OS\n\n")
+	fmt.Printf("  mdns-discover --output=f5-irule           - Show services as F5 BIG-IP data groups\n\n")
+	fmt.Printf("  mdns-discover --output=openvpn            - Show OpenVPN client configuration\n\n")
+	fmt.Printf("  mdns-discover --output=zerotier           - Show ZeroTier member authorizations\n\n")
+	fmt.Printf("  mdns-discover --output=zerotier \\\n")
+	fmt.Printf("  --zerotier-network=<id> \\\n")
+	fmt.Printf("  --zerotier-token=<token>                  - Authorize ZeroTier members\n\n")
+	fmt.Printf("  mdns-discover --output=tailscale          - Show subnets as tailscale set command\n\n")
+	fmt.Printf("  mdns-discover --output=tailscale \\\n")
+	fmt.Printf("  --tailscale-run                           - Advertise subnets with tailscale\n\n")
+	fmt.Printf("  mdns-discover --output=homeassistant      - Show devices as Home Assistant known_devices\n\n")
+	fmt.Printf("  mdns-discover --output=homekit            - Show HomeKit accessories as Homebridge JSON\n\n")
+	fmt.Printf("  mdns-discover --output=openhab            - Show devices as openHAB Things\n\n")
+	fmt.Printf("  mdns-discover --output=node-red           - Show HTTP services as Node-RED flow\n\n")
+	fmt.Printf("  mdns-discover --output=mqtt               - Show services as MQTT topic and payload\n\n")
+	fmt.Printf("  mdns-discover --output=mqtt \\\n")
+	fmt.Printf("  --mqtt-broker=<url>                       - Publish services to MQTT\n\n")
+	fmt.Printf("  mdns-discover --output=zigbee2mqtt        - Show MQTT broker for Zigbee2MQTT\n\n")
+	fmt.Printf("  mdns-discover --output=esphome            - Show ESPHome device configurations\n\n")
+	fmt.Printf("  mdns-discover --output=home-assistant-discovery \\\n")
+	fmt.Printf("  [--mqtt-broker=<url>]                     - Register services in Home Assistant\n\n")
+	fmt.Printf("  mdns-discover --output=docker-compose     - Show HTTP services as Docker Compose services\n\n")
+	fmt.Printf("  mdns-discover --output=supervisord        - Show services as supervisord programs\n\n")
+	fmt.Printf("  mdns-discover --output=systemd-unit       - Show services as systemd Environment=\n\n")
+	fmt.Printf("  mdns-discover --output=envfile            - Show services as .env file,\n")
+	fmt.Printf("                                              MDNS_<TYPE>=<address>:<port>, several\n")
+	fmt.Printf("                                              instances as MDNS_<TYPE>_1, MDNS_<TYPE>_2,\n")
+	fmt.Printf("                                              several addresses as MDNS_<TYPE>_ADDR_1\n\n")
+	fmt.Printf("  mdns-discover --output=ini                - Show services as INI file\n\n")
+	fmt.Printf("  mdns-discover --output=redis              - Show services as Redis commands\n\n")
+	fmt.Printf("  mdns-discover --output=redis \\\n")
+	fmt.Printf("  --redis-addr=<host:port> \\\n")
+	fmt.Printf("  [--redis-password=<password>]             - Cache services in Redis\n\n")
+	fmt.Printf("  mdns-discover --output=memcached          - Show services as memcached commands\n\n")
+	fmt.Printf("  mdns-discover --output=memcached \\\n")
+	fmt.Printf("  --memcached-addr=<host:port>              - Cache services in memcached\n\n")
+}
+
+func main() {
+	progname := os.Args[0]
+	version := "1"
+	filter := os.Getenv("MDNS_SERVICE_FILTER")
+	defaultOutput := string(OutputText)
+	if env := os.Getenv("MDNS_OUTPUT"); "" != env {
+		defaultOutput = env
+	}
+	defaultSeparator := " "
+	if env, ok := os.LookupEnv("MDNS_FIELD_SEPARATOR"); ok && "" != env {
+		defaultSeparator = env
+	}
+
+	dryRun := flag.Bool("dry-run", false, "Validate the configuration and exit without discovering")
+	output := flag.String("output", defaultOutput, "Output format, defaults to $MDNS_OUTPUT")
+	netboxURL := flag.String("netbox-url", "", "NetBox base URL to POST discovered addresses to")
+	netboxToken := flag.String("netbox-token", "", "NetBox API token")
+	syslogAddr := flag.String("syslog-addr", "", "Syslog server address, defaults to the local socket")
+	nginxWeightKey := flag.String("nginx-weight-key", "", "TXT record key holding the nginx server weight")
+	etcdEndpoints := flag.String("etcd-endpoints", "", "Comma separated etcd endpoints to write discovered services to")
+	etcdTTL := flag.Duration("etcd-ttl", time.Second*60, "TTL of keys written to etcd")
+	slackWebhook := flag.String("slack-webhook", "", "Slack incoming webhook URL")
+	knownServicesFile := flag.String("known-services-file", "", "File of known services, one key per line")
+	vaultAddr := flag.String("vault-addr", "", "Vault address to write discovered services to")
+	vaultToken := flag.String("vault-token", "", "Vault token")
+	vaultMount := flag.String("vault-mount", "secret", "Vault KV v2 mount")
+	zoneOrigin := flag.String("zone-origin", "local.", "Origin of the generated zone file or SkyDNS records")
+	zoneNameserver := flag.String("zone-nameserver", "", "Nameserver of the generated zone file, relative names are within --zone-origin")
+	zoneNameserverAddress := flag.String("zone-nameserver-address", "", "Address of --zone-nameserver for the glue record")
+	ipVersion := flag.String("ip-version", "", "Only show addresses of IP version 4 or 6, or all, defaults to 4 except for modes writing IPv6 records")
+	timeout := flag.Duration("timeout", discoverTimeout, "Time to browse for each service type")
+	concurrency := flag.Int("concurrency", defaultMaxConcurrent, "Number of service types browsed at the same time")
+	maxRuntime := flag.Duration("max-runtime", 0, "Give up waiting for --wait-for after this duration, 0 waits forever")
+	waitForService := flag.String("wait-for", "", "Wait until the given service type is discovered")
+	waitForHostname := flag.String("wait-for-hostname", "", "Also require the given hostname with --wait-for")
+	showProgress := flag.Bool("progress", false, "Show discovery progress on stderr")
+	cacheFile := flag.String("cache-file", "", "Save results to a file and reuse them within --cache-ttl")
+	cacheTTL := flag.Duration("cache-ttl", 0, "How long cached results are reused, 0 always refreshes")
+	compress := flag.Bool("compress", false, "Compress json, csv and tsv output with gzip")
+	outputFile := flag.String("output-file", "", "Write output to a file instead of stdout")
+	fieldSeparator := flag.String("field-separator", defaultSeparator, "Separator of text output fields, \\t for tab, defaults to $MDNS_FIELD_SEPARATOR")
+	columnNames := flag.String("column-name", "", "Rename CSV and TSV columns, e.g. hostname=Host,address=IP")
+	showEnv := flag.Bool("print-env", false, "Print the recognized environment variables to stderr")
+	pushgatewayURL := flag.String("pushgateway-url", "", "Prometheus Pushgateway URL")
+	pushgatewayJob := flag.String("pushgateway-job", "mdns-discover", "Job name for the Pushgateway")
+	tcpDaemon := flag.String("tcp-daemon", "ALL", "Daemon the hosts.allow rules apply to")
+	frrASN := flag.Uint("frr-asn", 65000, "BGP ASN of the generated FRR router block")
+	var maskedFields stringList
+	flag.Var(&maskedFields, "mask-field", "Replace the value of a field with *** in the output, repeatable")
+	lokiURL := flag.String("loki-url", "", "Grafana Loki URL")
+	opentsdbAddr := flag.String("opentsdb-addr", "", "OpenTSDB telnet API address")
+	fieldFormat := flag.String("field-format", "", "Format text output fields with fmt verbs, e.g. port=%05d,hostname=%.30s")
+	base64TXT := flag.Bool("base64-txt", false, "Encode TXT records with Base64")
+	gelfAddr := flag.String("gelf-addr", "", "Graylog GELF UDP input address")
+	sflowCollector := flag.String("sflow-collector", "", "sFlow collector address")
+	capnpText := flag.Bool("capnp-text", false, "Write the Cap'n Proto text format instead of the packed binary")
+	pythonModule := flag.Bool("python-module", false, "Write python output as a module defining services, for import")
+	jsonChunkSize := flag.Int("json-chunk-size", 1, "Number of records per json-stream line")
+	jsonTemplate := flag.String("json-template", "", "Go template for json-template output, receives the services")
+	nsupdateServer := flag.String("nsupdate-server", "", "DNS server to send nsupdate updates to")
+	nsupdateKey := flag.String("nsupdate-key", "", "TSIG key for nsupdate as [algorithm:]name:secret")
+	panosAPI := flag.String("panos-api", "", "PAN-OS firewall URL to set address objects with the XML API")
+	panosKey := flag.String("panos-key", "", "PAN-OS XML API key")
+	zerotierNetwork := flag.String("zerotier-network", "", "ZeroTier network ID to authorize discovered members in")
+	zerotierToken := flag.String("zerotier-token", "", "ZeroTier Central API token")
+	tailscaleRun := flag.Bool("tailscale-run", false, "Run tailscale set instead of printing the command")
+	mqttBroker := flag.String("mqtt-broker", "", "MQTT broker URL to publish discovered services to, e.g. mqtt://broker.local:1883")
+	redisAddr := flag.String("redis-addr", "", "Redis server to cache discovered services in, as host:port")
+	redisPassword := flag.String("redis-password", "", "Redis password")
+	memcachedAddr := flag.String("memcached-addr", "", "memcached server to cache discovered services in, as host:port")
+	flag.Parse()
+
+	if *showEnv {
+		printEnv(os.Stderr)
+	}
+
+	if len(flag.Args()) > 0 && "help" == flag.Arg(0) {
+		help(progname, version)
+	}
+
+	mode := OutputMode(*output)
+	if !validOutputMode(mode) {
+		log.Fatalln("Unknown output mode:", *output)
+	}
+	if *jsonChunkSize < 1 {
+		log.Fatalln("Invalid JSON chunk size:", *jsonChunkSize)
+	}
+	if OutputParquet == mode && "" == *outputFile {
+		log.Fatalln("Output mode parquet requires --output-file")
+	}
+	if OutputBINDZone == mode && "" == *zoneNameserver {
+		log.Fatalln("Output mode bind-zone requires --zone-nameserver")
+	}
+	if "" != *ipVersion && "4" != *ipVersion && "6" != *ipVersion && "all" != *ipVersion {
+		log.Fatalln("Invalid IP version:", *ipVersion)
+	}
+	for _, field := range maskedFields {
+		if !validOutputField(field) {
+			log.Fatalln("Unknown field to mask:", field)
+		}
+	}
+	fieldFormats, err := parseFieldFormats(*fieldFormat)
 	if err != nil {
-		log.Fatalln("Failed to initialize resolver:", err.Error())
-	}
-
-	entries := make(chan *zeroconf.ServiceEntry)
-	go func(results <-chan *zeroconf.ServiceEntry) {
-		for entry := range results {
-		    for n,addr := range entry.AddrIPv4 {
-			    fmt.Printf("%d %s", n, entry.HostName)
-			    fmt.Printf(" %s", addr)
-			    fmt.Printf(" %d", entry.Port)
-			    fmt.Printf(" %s", entry.Text)
-                fmt.Println()
-            }
+		log.Println("Invalid field format:", err.Error())
+		os.Exit(exitUsage)
+	}
+
+	var tmpl *template.Template
+	if OutputJSONTemplate == mode {
+		if "" == *jsonTemplate {
+			log.Println("Output mode json-template requires --json-template")
+			os.Exit(exitUsage)
+		}
+		tmpl, err = parseJSONTemplate(*jsonTemplate)
+		if err != nil {
+			log.Println("Invalid JSON template:", err.Error())
+			os.Exit(exitUsage)
 		}
-	}(entries)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
-	defer cancel()
-	err = resolver.Browse(ctx, name, "local.", entries)
-	if err != nil {
-		log.Fatalln("Failed to browse:", err.Error())
+	cfg := OutputConfig{
+		NetBoxURL:         *netboxURL,
+		NetBoxToken:       *netboxToken,
+		SyslogAddr:        *syslogAddr,
+		NginxWeightKey:    *nginxWeightKey,
+		EtcdEndpoints:     *etcdEndpoints,
+		EtcdTTL:           *etcdTTL,
+		SlackWebhook:      *slackWebhook,
+		KnownServicesFile: *knownServicesFile,
+		VaultAddr:         *vaultAddr,
+		VaultToken:        *vaultToken,
+		VaultMount:        *vaultMount,
+		ZoneOrigin:        *zoneOrigin,
+		ZoneNameserver:    *zoneNameserver,
+		ZoneGlueAddress:   *zoneNameserverAddress,
+		IPVersion:         outputIPVersion(mode, *ipVersion),
+		FieldSeparator:    strings.ReplaceAll(*fieldSeparator, `\t`, "\t"),
+		ColumnAliases:     parseColumnAliases(*columnNames),
+		PushgatewayURL:    *pushgatewayURL,
+		PushgatewayJob:    *pushgatewayJob,
+		TCPDaemon:         *tcpDaemon,
+		FRRASN:            *frrASN,
+		MaskFields:        maskedFields,
+		LokiURL:           *lokiURL,
+		FieldFormats:      fieldFormats,
+		Base64TXT:         *base64TXT,
+		GELFAddr:          *gelfAddr,
+		SFlowCollector:    *sflowCollector,
+		CapnpText:         *capnpText,
+		PythonModule:      *pythonModule,
+		JSONChunkSize:     *jsonChunkSize,
+		JSONTemplate:      tmpl,
+		NSUpdateServer:    *nsupdateServer,
+		NSUpdateKey:       *nsupdateKey,
+		PANOSAPI:          *panosAPI,
+		PANOSKey:          *panosKey,
+		ZeroTierNetwork:   *zerotierNetwork,
+		ZeroTierToken:     *zerotierToken,
+		TailscaleRun:      *tailscaleRun,
+		MQTTBroker:        *mqttBroker,
+		RedisAddr:         *redisAddr,
+		RedisPassword:     *redisPassword,
+		MemcachedAddr:     *memcachedAddr,
 	}
 
-	<-ctx.Done()
-}
+	dcfg := DiscoverConfig{
+		Timeout:       *timeout,
+		MaxConcurrent: *concurrency,
+		MaxAttempts:   defaultResolverAttempts,
+		PrintResults:  OutputText == mode || OutputOpenTSDBTelnet == mode || OutputJSONStream == mode,
+		IPVersion:     strings.TrimSuffix(*ipVersion, "all"),
+	}
+	if dcfg.MaxConcurrent < 1 {
+		log.Fatalln("Invalid concurrency:", *concurrency)
+	}
 
-func help(name string, version string) {
-    fmt.Printf("\n%s version: %s\n\n", name, version)
-    fmt.Printf(" Usage:\n\n")
-    fmt.Printf("  mdns-discover                             - Show all discovered devices\n\n")
-    fmt.Printf("  MDNS_SERVICE_FILTER=\"_workstation._tcp\" \\\n")
-    fmt.Printf("  mdns-discover                             - Show filtered devices\n\n")
-}
+	filters := services[:]
+	if "" != filter {
+		filters = []string{filter}
+	}
 
-func main() {
-    progname := os.Args[0]
-    version := "1"
-	filter := os.Getenv("MDNS_SERVICE_FILTER")
+	if *dryRun {
+		for _, name := range filters {
+			if err := validateServiceType(name); err != nil {
+				log.Fatalln("Invalid service type:", err.Error())
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Would discover %d service types with timeout=%s, concurrency=%d, output=%s\n",
+			len(filters), dcfg.Timeout, dcfg.MaxConcurrent, mode)
+		for _, name := range filters {
+			fmt.Fprintf(os.Stderr, "  %s\n", name)
+		}
+		os.Exit(exitOK)
+	}
 
-    if  len(os.Args) > 1 && "help" == os.Args[1] {
-        help(progname, version)
-    }
+	if *compress && OutputJSON != mode && OutputCSV != mode && OutputTSV != mode {
+		log.Println("Warning: --compress only applies to json, csv and tsv output")
+		*compress = false
+	}
 
-    if "" != filter {
-	    discover(filter)
-        os.Exit(0)
-    }
+	var out io.Writer = os.Stdout
+	if "" != *outputFile {
+		path := *outputFile
+		if *compress && !strings.HasSuffix(path, ".gz") {
+			path += ".gz"
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			log.Fatalln("Failed to create output file:", err.Error())
+		}
+		defer f.Close()
+		out = f
+	}
+	if *compress {
+		zw := gzip.NewWriter(out)
+		defer func() {
+			if err := zw.Close(); err != nil {
+				log.Println("Failed to write output:", err.Error())
+			}
+		}()
+		out = zw
+	}
+
+	if OutputOpenTSDBTelnet == mode && "" != *opentsdbAddr {
+		var closeConn func()
+		out, closeConn = dialOpenTSDB(*opentsdbAddr, out)
+		defer closeConn()
+	}
+
+	binary := binaryOutputModes[mode] && !(OutputCapNProto == mode && cfg.CapnpText)
+	if binary && "" == *outputFile && isTerminal(os.Stdout) {
+		log.Printf("Warning: writing binary %s output to a terminal, use --output-file or a pipe\n", mode)
+	}
 
-    for _, filter := range services {
-	    discover(filter)
-    }
+	if "" != *waitForService {
+		wcfg := dcfg
+		wcfg.ServiceType = *waitForService
+		found, ok := waitFor(wcfg, *waitForHostname, *maxRuntime)
+		if !ok {
+			log.Println("Service not found:", *waitForService)
+			os.Exit(exitNotFound)
+		}
+		err := writeOutput(out, mode, prepareServices(found, cfg), cfg)
+		if err != nil {
+			log.Fatalln("Failed to write output:", err.Error())
+		}
+		return
+	}
+
+	if "" != *cacheFile {
+		cached, ok := readCache(*cacheFile, *cacheTTL, filters)
+		if ok {
+			log.Println("Using cached results from", *cacheFile)
+			err := writeOutput(out, mode, prepareServices(cached, cfg), cfg)
+			if err != nil {
+				log.Fatalln("Failed to write output:", err.Error())
+			}
+			return
+		}
+	}
+
+	var p *progress
+	if *showProgress {
+		p = startProgress(os.Stderr)
+	}
+
+	stream := newJSONStream(out, cfg.JSONChunkSize)
+	discovered := discoverAll(filters, dcfg, p, func(found []Service) {
+		switch mode {
+		case OutputText:
+			p.Clear()
+			writeText(out, prepareServices(found, cfg), cfg)
+		case OutputOpenTSDBTelnet:
+			err := writeOpenTSDB(out, prepareServices(found, cfg))
+			if err != nil {
+				log.Println("Warning: failed to write output:", err.Error())
+			}
+		case OutputJSONStream:
+			p.Clear()
+			err := stream.add(prepareServices(found, cfg))
+			if err != nil {
+				log.Println("Warning: failed to write output:", err.Error())
+			}
+		}
+	})
+	p.Stop()
+	if OutputJSONStream == mode {
+		err := stream.flush()
+		if err != nil {
+			log.Fatalln("Failed to write output:", err.Error())
+		}
+	}
+
+	if "" != *cacheFile {
+		err := writeCache(*cacheFile, discovered)
+		if err != nil {
+			log.Println("Warning: failed to write cache file:", err.Error())
+		}
+	}
+	discovered = prepareServices(discovered, cfg)
+
+	// Streaming modes already wrote each service type as it finished
+	if dcfg.PrintResults {
+		return
+	}
+	err = writeOutput(out, mode, discovered, cfg)
+	if err != nil {
+		log.Fatalln("Failed to write output:", err.Error())
+	}
 }
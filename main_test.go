@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Run main() in a child process of the test binary when set
+const runMainEnv = "MDNS_DISCOVER_TEST_MAIN"
+
+func TestMain(m *testing.M) {
+	if "" != os.Getenv(runMainEnv) {
+		os.Args = append([]string{"mdns-discover"}, os.Args[1:]...)
+		main()
+		os.Exit(exitOK)
+	}
+	os.Exit(m.Run())
+}
+
+// Run mdns-discover with the given environment and arguments, serving
+// the sample services from a cache file so no network is needed
+func runMain(t *testing.T, env []string, args ...string) []byte {
+	t.Helper()
+	cacheFile := filepath.Join(t.TempDir(), "cache.json")
+	err := writeCache(cacheFile, []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+			Text: []string{"path=/"}, TxtMap: map[string]string{"path": "/"}},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80,
+			Text: []string{"path=/"}, TxtMap: map[string]string{"path": "/"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args = append([]string{"--cache-file=" + cacheFile, "--cache-ttl=1h"}, args...)
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), append(env, runMainEnv+"=1", "MDNS_SERVICE_FILTER=_http._tcp")...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("mdns-discover %v: %v\n%s", args, err, stderr.String())
+	}
+	return out
+}
+
+func TestMDNSOutputDefault(t *testing.T) {
+	out := runMain(t, []string{"MDNS_OUTPUT=json"})
+
+	var discovered []Service
+	err := json.Unmarshal(out, &discovered)
+	if err != nil {
+		t.Fatalf("MDNS_OUTPUT=json wrote %q: %v", out, err)
+	}
+	if len(discovered) != 1 || discovered[0].Hostname != "host1.local." {
+		t.Errorf("got %+v", discovered)
+	}
+}
+
+func TestCompressOutputFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "services.json")
+	runMain(t, nil, "--output=json", "--compress", "--output-file="+outputFile)
+
+	f, err := os.Open(outputFile + ".gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var discovered []Service
+	err = json.Unmarshal(data, &discovered)
+	if err != nil {
+		t.Fatalf("gunzipped output %q is not JSON: %v", data, err)
+	}
+	if len(discovered) != 1 || discovered[0].Hostname != "host1.local." {
+		t.Errorf("got %+v", discovered)
+	}
+}
+
+func TestIPVersionDefault(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--output=json"}, []string{"192.168.1.10"}},
+		{[]string{"--output=json", "--ip-version=6"}, []string{"fe80::1"}},
+		{[]string{"--output=json", "--ip-version=all"}, []string{"192.168.1.10", "fe80::1"}},
+	} {
+		var discovered []Service
+		err := json.Unmarshal(runMain(t, nil, tc.args...), &discovered)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, s := range discovered {
+			got = append(got, s.Address)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got addresses %v, want %v", tc.args, got, tc.want)
+		}
+	}
+
+	out := string(runMain(t, nil, "--output=bind-zone", "--zone-nameserver=host1"))
+	if !strings.Contains(out, "host1\tIN\tA\t192.168.1.10\n") || !strings.Contains(out, "host1\tIN\tAAAA\tfe80::1\n") {
+		t.Errorf("bind-zone lacks the A or AAAA record:\n%s", out)
+	}
+}
+
+func TestIPv6OutputModes(t *testing.T) {
+	for _, tc := range []struct {
+		mode string
+		want []string
+	}{
+		{"junos", []string{
+			"set system static-host-mapping host1 inet 192.168.1.10\n",
+			"set system static-host-mapping host1 inet6 fe80::1\n",
+		}},
+		{"cisco-ios", []string{"ip host host1 192.168.1.10\n", "ipv6 host host1 fe80::1\n"}},
+		{"coredns-hosts", []string{"    192.168.1.10 host1.local\n", "    fe80::1 host1.local\n"}},
+		{"docker-dns", []string{`  - "host1.local:192.168.1.10"` + "\n", `  - "host1.local:[fe80::1]"` + "\n"}},
+	} {
+		out := string(runMain(t, nil, "--output="+tc.mode))
+		for _, line := range tc.want {
+			if !strings.Contains(out, line) {
+				t.Errorf("%s lacks %q:\n%s", tc.mode, line, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,619 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// OutputMode selects how discovered services are written
+type OutputMode string
+
+const (
+	OutputText              OutputMode = "text"
+	OutputNetBox            OutputMode = "netbox-json"
+	OutputSyslog            OutputMode = "syslog"
+	OutputInflux            OutputMode = "influxdb"
+	OutputHAProxy           OutputMode = "haproxy"
+	OutputNginx             OutputMode = "nginx"
+	OutputEtcd              OutputMode = "etcd-json"
+	OutputZabbix            OutputMode = "zabbix"
+	OutputKubernetes        OutputMode = "kubernetes"
+	OutputSlack             OutputMode = "slack"
+	OutputOPNsense          OutputMode = "opnsense-xml"
+	OutputVault             OutputMode = "vault"
+	OutputTerraformHCL      OutputMode = "terraform-hcl"
+	OutputBINDZone          OutputMode = "bind-zone"
+	OutputJSON              OutputMode = "json"
+	OutputDnsmasq           OutputMode = "dnsmasq"
+	OutputCoreDNSHosts      OutputMode = "coredns-hosts"
+	OutputCaddy             OutputMode = "caddyfile"
+	OutputCSV               OutputMode = "csv"
+	OutputTSV               OutputMode = "tsv"
+	OutputDockerExtraHosts  OutputMode = "docker-dns"
+	OutputSSDP              OutputMode = "ssdp"
+	OutputPrometheusGateway OutputMode = "prometheus-gateway"
+	OutputRSS               OutputMode = "rss"
+	OutputHiera             OutputMode = "hiera"
+	OutputHostsAllow        OutputMode = "hosts-allow"
+	OutputFRR               OutputMode = "frr"
+	OutputSalt              OutputMode = "salt"
+	OutputAnsibleVars       OutputMode = "ansible-vars"
+	OutputChef              OutputMode = "chef"
+	OutputCheckMK           OutputMode = "checkmk-local"
+	OutputIcinga2           OutputMode = "icinga2"
+	OutputTraefik           OutputMode = "traefik"
+	OutputPuppet            OutputMode = "puppet-manifest"
+	OutputTelegraf          OutputMode = "telegraf"
+	OutputLoki              OutputMode = "loki"
+	OutputCoreDNSForward    OutputMode = "coredns-forward"
+	OutputPowerShell        OutputMode = "powershell"
+	OutputRuby              OutputMode = "ruby"
+	OutputPython            OutputMode = "python"
+	OutputiOSShortcuts      OutputMode = "ios-shortcuts"
+	OutputWeaveScope        OutputMode = "weave-scope"
+	OutputNagiosConfig      OutputMode = "nagios-config"
+	OutputOpenTSDBTelnet    OutputMode = "opentsdb-telnet"
+	OutputCoreDNSetcd       OutputMode = "coredns-etcd"
+	OutputJSONLD            OutputMode = "json-ld"
+	OutputGELF              OutputMode = "gelf"
+	OutputSocketIO          OutputMode = "socket-io"
+	OutputSFlow             OutputMode = "sflow"
+	OutputJSONPointer       OutputMode = "json-pointer"
+	OutputAvro              OutputMode = "avro"
+	OutputParquet           OutputMode = "parquet"
+	OutputMessagePack       OutputMode = "messagepack"
+	OutputCBOR              OutputMode = "cbor"
+	OutputProtobuf          OutputMode = "protobuf"
+	OutputProtobufJSON      OutputMode = "protobuf-json"
+	OutputFlatBuffers       OutputMode = "flatbuffers"
+	OutputCapNProto         OutputMode = "cap-n-proto"
+	OutputJSONStream        OutputMode = "json-stream"
+	OutputJSONTemplate      OutputMode = "json-template"
+	OutputNSUpdate          OutputMode = "dns-zone-dynamic"
+	OutputWireGuard         OutputMode = "wireguard"
+	OutputUnbound           OutputMode = "unbound"
+	OutputSystemdResolved   OutputMode = "systemd-resolved"
+	OutputAristaEOS         OutputMode = "arista-eos"
+	OutputCiscoIOS          OutputMode = "cisco-ios"
+	OutputJunos             OutputMode = "junos"
+	OutputPANOS             OutputMode = "panos"
+	OutputF5iRule           OutputMode = "f5-irule"
+	OutputOpenVPN           OutputMode = "openvpn"
+	OutputZeroTier          OutputMode = "zerotier"
+	OutputTailscale         OutputMode = "tailscale"
+	OutputHomeAssistant     OutputMode = "homeassistant"
+	OutputHomeKit           OutputMode = "homekit"
+	OutputOpenHAB           OutputMode = "openhab"
+	OutputNodeRed           OutputMode = "node-red"
+	OutputMQTT              OutputMode = "mqtt"
+	OutputZigbee2MQTT       OutputMode = "zigbee2mqtt"
+	OutputESPHome           OutputMode = "esphome"
+	OutputHAMQTTDiscovery   OutputMode = "home-assistant-discovery"
+	OutputDockerCompose     OutputMode = "docker-compose"
+	OutputSupervisord       OutputMode = "supervisord"
+	OutputSystemdUnit       OutputMode = "systemd-unit"
+	OutputEnvFile           OutputMode = "envfile"
+	OutputINI               OutputMode = "ini"
+	OutputRedis             OutputMode = "redis"
+	OutputMemcached         OutputMode = "memcached"
+)
+
+var outputModes = []OutputMode{
+	OutputText,
+	OutputNetBox,
+	OutputSyslog,
+	OutputInflux,
+	OutputHAProxy,
+	OutputNginx,
+	OutputEtcd,
+	OutputZabbix,
+	OutputKubernetes,
+	OutputSlack,
+	OutputOPNsense,
+	OutputVault,
+	OutputTerraformHCL,
+	OutputBINDZone,
+	OutputJSON,
+	OutputDnsmasq,
+	OutputCoreDNSHosts,
+	OutputCaddy,
+	OutputCSV,
+	OutputTSV,
+	OutputDockerExtraHosts,
+	OutputSSDP,
+	OutputPrometheusGateway,
+	OutputRSS,
+	OutputHiera,
+	OutputHostsAllow,
+	OutputFRR,
+	OutputSalt,
+	OutputAnsibleVars,
+	OutputChef,
+	OutputCheckMK,
+	OutputIcinga2,
+	OutputTraefik,
+	OutputPuppet,
+	OutputTelegraf,
+	OutputLoki,
+	OutputCoreDNSForward,
+	OutputPowerShell,
+	OutputRuby,
+	OutputPython,
+	OutputiOSShortcuts,
+	OutputWeaveScope,
+	OutputNagiosConfig,
+	OutputOpenTSDBTelnet,
+	OutputCoreDNSetcd,
+	OutputJSONLD,
+	OutputGELF,
+	OutputSocketIO,
+	OutputSFlow,
+	OutputJSONPointer,
+	OutputAvro,
+	OutputParquet,
+	OutputMessagePack,
+	OutputCBOR,
+	OutputProtobuf,
+	OutputProtobufJSON,
+	OutputFlatBuffers,
+	OutputCapNProto,
+	OutputJSONStream,
+	OutputJSONTemplate,
+	OutputNSUpdate,
+	OutputWireGuard,
+	OutputUnbound,
+	OutputSystemdResolved,
+	OutputAristaEOS,
+	OutputCiscoIOS,
+	OutputJunos,
+	OutputPANOS,
+	OutputF5iRule,
+	OutputOpenVPN,
+	OutputZeroTier,
+	OutputTailscale,
+	OutputHomeAssistant,
+	OutputHomeKit,
+	OutputOpenHAB,
+	OutputNodeRed,
+	OutputMQTT,
+	OutputZigbee2MQTT,
+	OutputESPHome,
+	OutputHAMQTTDiscovery,
+	OutputDockerCompose,
+	OutputSupervisord,
+	OutputSystemdUnit,
+	OutputEnvFile,
+	OutputINI,
+	OutputRedis,
+	OutputMemcached,
+}
+
+// Fields of a Service in output order
+var outputFields = []string{
+	"service_type",
+	"instance",
+	"hostname",
+	"address",
+	"port",
+	"txt",
+}
+
+func validOutputField(field string) bool {
+	for _, f := range outputFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Format a single field of a service as string
+func fieldValue(s Service, field string) string {
+	switch field {
+	case "service_type":
+		return s.ServiceType
+	case "instance":
+		return s.Instance
+	case "hostname":
+		return s.Hostname
+	case "address":
+		return s.Address
+	case "port":
+		return strconv.Itoa(s.Port)
+	case "txt":
+		return strings.Join(s.Text, " ")
+	}
+	return ""
+}
+
+// Parse field=format pairs, checking each format verb against the type
+// of the field: int for port, string for all others
+func parseFieldFormats(value string) (map[string]string, error) {
+	formats := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if "" == strings.TrimSpace(pair) {
+			continue
+		}
+		field, format, ok := strings.Cut(pair, "=")
+		field = strings.TrimSpace(field)
+		if !ok || "" == format {
+			return nil, fmt.Errorf("missing format for field: %s", pair)
+		}
+		if !validOutputField(field) {
+			return nil, fmt.Errorf("unknown field: %s", field)
+		}
+		var sample interface{} = ""
+		if "port" == field {
+			sample = 0
+		}
+		if strings.Contains(fmt.Sprintf(format, sample), "%!") {
+			return nil, fmt.Errorf("format %q does not match field %s", format, field)
+		}
+		formats[field] = format
+	}
+	return formats, nil
+}
+
+// Apply the custom format of a field, if any, to its value
+func formatField(field string, value interface{}, formats map[string]string) string {
+	if format, ok := formats[field]; ok {
+		return fmt.Sprintf(format, value)
+	}
+	return fmt.Sprint(value)
+}
+
+// OutputConfig holds the settings of the individual output modes
+type OutputConfig struct {
+	NetBoxURL         string
+	NetBoxToken       string
+	SyslogAddr        string
+	NginxWeightKey    string
+	EtcdEndpoints     string
+	EtcdTTL           time.Duration
+	SlackWebhook      string
+	KnownServicesFile string
+	VaultAddr         string
+	VaultToken        string
+	VaultMount        string
+	ZoneOrigin        string
+	ZoneNameserver    string
+	ZoneGlueAddress   string
+	IPVersion         string
+	FieldSeparator    string
+	ColumnAliases     map[string]string
+	PushgatewayURL    string
+	PushgatewayJob    string
+	TCPDaemon         string
+	FRRASN            uint
+	MaskFields        []string
+	LokiURL           string
+	FieldFormats      map[string]string
+	Base64TXT         bool
+	GELFAddr          string
+	SFlowCollector    string
+	CapnpText         bool
+	PythonModule      bool
+	JSONChunkSize     int
+	JSONTemplate      *template.Template
+	NSUpdateServer    string
+	NSUpdateKey       string
+	PANOSAPI          string
+	PANOSKey          string
+	ZeroTierNetwork   string
+	ZeroTierToken     string
+	TailscaleRun      bool
+	MQTTBroker        string
+	RedisAddr         string
+	RedisPassword     string
+	MemcachedAddr     string
+}
+
+// Modes writing AAAA or other IPv6 specific records, they show
+// IPv6 addresses without --ip-version
+var ipv6OutputModes = map[OutputMode]bool{
+	OutputBINDZone:         true,
+	OutputNSUpdate:         true,
+	OutputUnbound:          true,
+	OutputJunos:            true,
+	OutputCiscoIOS:         true,
+	OutputCoreDNSHosts:     true,
+	OutputDockerExtraHosts: true,
+}
+
+// IP version of the addresses mode shows for --ip-version, IPv4 only
+// by default and all addresses for all or modes writing IPv6 records
+func outputIPVersion(mode OutputMode, version string) string {
+	switch {
+	case "all" == version:
+		return ""
+	case "" == version && !ipv6OutputModes[mode]:
+		return "4"
+	}
+	return version
+}
+
+// Apply the IP version filter, TXT encoding and field masks before writing
+func prepareServices(discovered []Service, cfg OutputConfig) []Service {
+	discovered = filterIPVersion(discovered, cfg.IPVersion)
+	if cfg.Base64TXT {
+		discovered = encodeTxtBase64(discovered)
+	}
+	return maskFields(discovered, cfg.MaskFields)
+}
+
+// Modes writing binary data that should not end up on a terminal
+var binaryOutputModes = map[OutputMode]bool{
+	OutputAvro:        true,
+	OutputParquet:     true,
+	OutputMessagePack: true,
+	OutputCBOR:        true,
+	OutputProtobuf:    true,
+	OutputFlatBuffers: true,
+	OutputCapNProto:   true,
+}
+
+func validOutputMode(mode OutputMode) bool {
+	for _, m := range outputModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+func writeOutput(w io.Writer, mode OutputMode, discovered []Service, cfg OutputConfig) error {
+	switch mode {
+	case OutputText:
+		writeText(w, discovered, cfg)
+		return nil
+	case OutputNetBox:
+		return writeNetBox(w, discovered, cfg)
+	case OutputSyslog:
+		return writeSyslog(w, discovered, cfg)
+	case OutputInflux:
+		return writeInflux(w, discovered)
+	case OutputHAProxy:
+		return writeHAProxy(w, discovered)
+	case OutputNginx:
+		return writeNginx(w, discovered, cfg)
+	case OutputEtcd:
+		return writeEtcd(w, discovered, cfg)
+	case OutputZabbix:
+		return writeZabbix(w, discovered)
+	case OutputKubernetes:
+		return writeKubernetes(w, discovered)
+	case OutputSlack:
+		return writeSlack(w, discovered, cfg)
+	case OutputOPNsense:
+		return writeOPNsense(w, discovered)
+	case OutputVault:
+		return writeVault(w, discovered, cfg)
+	case OutputTerraformHCL:
+		return writeTerraformHCL(w, discovered)
+	case OutputBINDZone:
+		return writeBINDZone(w, discovered, cfg)
+	case OutputJSON:
+		return writeJSON(w, discovered)
+	case OutputDnsmasq:
+		return writeDnsmasq(w, discovered, cfg)
+	case OutputCoreDNSHosts:
+		return writeCoreDNSHosts(w, discovered)
+	case OutputCaddy:
+		return writeCaddy(w, discovered)
+	case OutputCSV:
+		return writeCSV(w, discovered, cfg, ',')
+	case OutputTSV:
+		return writeCSV(w, discovered, cfg, '\t')
+	case OutputDockerExtraHosts:
+		return writeDockerExtraHosts(w, discovered)
+	case OutputSSDP:
+		return writeSSDP(w, discovered)
+	case OutputPrometheusGateway:
+		return writePushgateway(w, discovered, cfg)
+	case OutputRSS:
+		return writeRSS(w, discovered)
+	case OutputHiera:
+		return writeHiera(w, discovered)
+	case OutputHostsAllow:
+		return writeHostsAllow(w, discovered, cfg)
+	case OutputFRR:
+		return writeFRR(w, discovered, cfg)
+	case OutputSalt:
+		return writeSalt(w, discovered)
+	case OutputAnsibleVars:
+		return writeAnsibleVars(w, discovered)
+	case OutputChef:
+		return writeChef(w, discovered)
+	case OutputCheckMK:
+		return writeCheckMK(w, discovered)
+	case OutputIcinga2:
+		return writeIcinga2(w, discovered)
+	case OutputTraefik:
+		return writeTraefik(w, discovered)
+	case OutputPuppet:
+		return writePuppet(w, discovered)
+	case OutputTelegraf:
+		return writeTelegraf(w, discovered)
+	case OutputLoki:
+		return writeLoki(w, discovered, cfg)
+	case OutputCoreDNSForward:
+		return writeCoreDNSForward(w, discovered)
+	case OutputPowerShell:
+		return writePowerShell(w, discovered)
+	case OutputRuby:
+		return writeRuby(w, discovered)
+	case OutputPython:
+		return writePython(w, discovered, cfg.PythonModule)
+	case OutputiOSShortcuts:
+		return writeShortcuts(w, discovered)
+	case OutputWeaveScope:
+		return writeWeaveScope(w, discovered)
+	case OutputNagiosConfig:
+		return writeNagiosConfig(w, discovered)
+	case OutputOpenTSDBTelnet:
+		return writeOpenTSDB(w, discovered)
+	case OutputCoreDNSetcd:
+		return writeCoreDNSEtcd(w, discovered, cfg)
+	case OutputJSONLD:
+		return writeJSONLD(w, discovered)
+	case OutputGELF:
+		return writeGELF(w, discovered, cfg)
+	case OutputSocketIO:
+		return writeSocketIO(w, discovered)
+	case OutputSFlow:
+		return writeSFlow(w, discovered, cfg)
+	case OutputJSONPointer:
+		return writeJSONPointer(w, discovered)
+	case OutputAvro:
+		return writeAvro(w, discovered)
+	case OutputParquet:
+		return writeParquet(w, discovered)
+	case OutputMessagePack:
+		return writeMessagePack(w, discovered)
+	case OutputCBOR:
+		return writeCBOR(w, discovered)
+	case OutputProtobuf:
+		return writeProtobuf(w, discovered)
+	case OutputProtobufJSON:
+		return writeProtobufJSON(w, discovered)
+	case OutputFlatBuffers:
+		return writeFlatBuffers(w, discovered)
+	case OutputCapNProto:
+		return writeCapnp(w, discovered, cfg)
+	case OutputJSONStream:
+		return writeJSONStream(w, discovered, cfg)
+	case OutputJSONTemplate:
+		return writeJSONTemplate(w, discovered, cfg)
+	case OutputNSUpdate:
+		return writeNSUpdate(w, discovered, cfg)
+	case OutputWireGuard:
+		return writeWireGuard(w, discovered)
+	case OutputUnbound:
+		return writeUnbound(w, discovered)
+	case OutputSystemdResolved:
+		return writeSystemdResolved(w, discovered)
+	case OutputAristaEOS:
+		return writeAristaEOS(w, discovered)
+	case OutputCiscoIOS:
+		return writeCiscoIOS(w, discovered)
+	case OutputJunos:
+		return writeJunos(w, discovered)
+	case OutputPANOS:
+		return writePANOS(w, discovered, cfg)
+	case OutputF5iRule:
+		return writeF5iRule(w, discovered)
+	case OutputOpenVPN:
+		return writeOpenVPN(w, discovered)
+	case OutputZeroTier:
+		return writeZeroTier(w, discovered, cfg)
+	case OutputTailscale:
+		return writeTailscale(w, discovered, cfg)
+	case OutputHomeAssistant:
+		return writeHomeAssistant(w, discovered)
+	case OutputHomeKit:
+		return writeHomeKit(w, discovered)
+	case OutputOpenHAB:
+		return writeOpenHAB(w, discovered)
+	case OutputNodeRed:
+		return writeNodeRed(w, discovered)
+	case OutputMQTT:
+		return writeMQTT(w, discovered, cfg)
+	case OutputZigbee2MQTT:
+		return writeZigbee2MQTT(w, discovered)
+	case OutputESPHome:
+		return writeESPHome(w, discovered)
+	case OutputHAMQTTDiscovery:
+		return writeHAMQTTDiscovery(w, discovered, cfg)
+	case OutputDockerCompose:
+		return writeDockerCompose(w, discovered)
+	case OutputSupervisord:
+		return writeSupervisord(w, discovered)
+	case OutputSystemdUnit:
+		return writeSystemdUnit(w, discovered)
+	case OutputEnvFile:
+		return writeEnvFile(w, discovered)
+	case OutputINI:
+		return writeINI(w, discovered)
+	case OutputRedis:
+		return writeRedis(w, discovered, cfg)
+	case OutputMemcached:
+		return writeMemcached(w, discovered, cfg)
+	}
+	return fmt.Errorf("unknown output mode: %s", mode)
+}
+
+// Print one line per address, numbered per service instance
+func writeText(w io.Writer, discovered []Service, cfg OutputConfig) {
+	index := make(map[string]int)
+	for _, s := range discovered {
+		key := s.ServiceType + "/" + s.Instance
+		fmt.Fprintln(w, buildOutputLine(index[key], s, cfg.FieldSeparator, cfg.FieldFormats))
+		index[key]++
+	}
+}
+
+// Join the fields of a text output line with sep, applying custom field formats
+func buildOutputLine(index int, s Service, sep string, formats map[string]string) string {
+	parts := []string{
+		strconv.Itoa(index),
+		formatField("hostname", s.Hostname, formats),
+		formatField("address", s.Address, formats),
+		formatField("port", s.Port, formats),
+		formatField("txt", fmt.Sprint(s.Text), formats),
+	}
+	return strings.Join(parts, sep)
+}
+
+// Group services by service type, keeping the order of discovery
+func groupByServiceType(discovered []Service) ([]string, map[string][]Service) {
+	var types []string
+	groups := make(map[string][]Service)
+	for _, s := range discovered {
+		if _, ok := groups[s.ServiceType]; !ok {
+			types = append(types, s.ServiceType)
+		}
+		groups[s.ServiceType] = append(groups[s.ServiceType], s)
+	}
+	return types, groups
+}
+
+// Turn a service type into an environment variable name,
+// e.g. "_http._tcp" becomes "HTTP_TCP"
+func envName(serviceType string) string {
+	name := strings.ToUpper(strings.ReplaceAll(sanitizeName(serviceType), "-", "_"))
+	if "" == name || ('0' <= name[0] && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// Turn a service type or hostname into a name made of
+// letters, digits and dashes, e.g. "_http._tcp" becomes "http-tcp"
+func sanitizeName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.TrimSuffix(name, ".") {
+		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// Quote a string for POSIX shells
+func shellQuote(s string) string {
+	if "" != s && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,@") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ansibleService struct {
+	Hostname    string   `yaml:"hostname"`
+	Address     string   `yaml:"address"`
+	Port        int      `yaml:"port"`
+	ServiceType string   `yaml:"service_type"`
+	Txt         []string `yaml:"txt"`
+}
+
+// Write a variables file for ansible-playbook --extra-vars with
+// all services in the mdns_services list
+func writeAnsibleVars(w io.Writer, discovered []Service) error {
+	services := make([]ansibleService, 0, len(discovered))
+	for _, s := range discovered {
+		txt := s.Text
+		if txt == nil {
+			txt = []string{}
+		}
+		services = append(services, ansibleService{
+			Hostname:    s.Hostname,
+			Address:     s.Address,
+			Port:        s.Port,
+			ServiceType: s.ServiceType,
+			Txt:         txt,
+		})
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(map[string]interface{}{
+		"mdns_services": services,
+	})
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Format a MAC address in the dotted notation of EOS, e.g. 0011.2233.4455
+func eosMAC(mac net.HardwareAddr) string {
+	s := strings.ReplaceAll(mac.String(), ":", "")
+	return s[0:4] + "." + s[4:8] + "." + s[8:12]
+}
+
+// Write static ARP entries in the default VRF for all IPv4 addresses, the
+// MAC addresses are looked up in the local ARP table. Addresses without an
+// entry, e.g. hosts on another subnet, are written as comments
+func writeAristaEOS(w io.Writer, discovered []Service) error {
+	var addresses []string
+	seen := make(map[string]bool)
+	hostnames := make(map[string]string)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil || ip.To4() == nil || seen[s.Address] {
+			continue
+		}
+		seen[s.Address] = true
+		addresses = append(addresses, s.Address)
+		hostnames[s.Address] = strings.TrimSuffix(s.Hostname, ".")
+	}
+
+	macs := lookupMACs(addresses)
+	for _, address := range addresses {
+		mac, ok := macs[address]
+		if !ok || len(mac) != 6 {
+			fmt.Fprintf(w, "! %s (%s): no MAC address in the ARP table\n", address, hostnames[address])
+			continue
+		}
+		_, err := fmt.Fprintf(w, "arp vrf default %s %s arpa\n", address, eosMAC(mac))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
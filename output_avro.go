@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/hamba/avro/v2/ocf"
+)
+
+// Avro types of the Go types used in Service
+func avroType(t reflect.Type) (interface{}, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Int:
+		return "int", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Slice:
+		items, err := avroType(t.Elem())
+		return map[string]interface{}{"type": "array", "items": items}, err
+	case reflect.Map:
+		values, err := avroType(t.Elem())
+		return map[string]interface{}{"type": "map", "values": values}, err
+	}
+	return nil, fmt.Errorf("no avro type for %s", t)
+}
+
+// Name of a Service field in json and Avro
+func avroFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// Derive the Avro record schema from the fields and json names of Service
+func avroSchema() (string, error) {
+	t := reflect.TypeOf(Service{})
+	var fields []map[string]interface{}
+	for i := 0; i < t.NumField(); i++ {
+		typ, err := avroType(t.Field(i).Type)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, map[string]interface{}{"name": avroFieldName(t.Field(i)), "type": typ})
+	}
+	schema, err := json.Marshal(map[string]interface{}{
+		"type":      "record",
+		"name":      "Service",
+		"namespace": "mdns_discover",
+		"fields":    fields,
+	})
+	return string(schema), err
+}
+
+// Convert a service to a generic record matching avroSchema
+func avroRecord(s Service) map[string]interface{} {
+	v := reflect.ValueOf(s)
+	record := make(map[string]interface{}, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		record[avroFieldName(v.Type().Field(i))] = v.Field(i).Interface()
+	}
+	return record
+}
+
+// Write services as an Avro object container file with the schema embedded
+func writeAvro(w io.Writer, discovered []Service) error {
+	schema, err := avroSchema()
+	if err != nil {
+		return err
+	}
+	enc, err := ocf.NewEncoder(schema, w)
+	if err != nil {
+		return err
+	}
+	for _, s := range discovered {
+		if err := enc.Encode(avroRecord(s)); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Quote a TXT record as a DNS character string
+func zoneTXTString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Make a hostname relative to the zone origin, names outside
+// the origin stay fully qualified
+func zoneName(hostname string, origin string) string {
+	name := strings.TrimSuffix(hostname, ".") + "."
+	if name == origin {
+		return "@"
+	}
+	if strings.HasSuffix(name, "."+origin) {
+		return strings.TrimSuffix(name, "."+origin)
+	}
+	return name
+}
+
+// Address record of a hostname in the zone
+func zoneAddressRecord(name string, address string) (string, bool) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return "", false
+	}
+	rrtype := "A"
+	if ip.To4() == nil {
+		rrtype = "AAAA"
+	}
+	return fmt.Sprintf("%s\tIN\t%s\t%s", name, rrtype, address), true
+}
+
+// Write a zone file with A and AAAA records per hostname and address
+// and the TXT records of each hostname. The zone is served by
+// cfg.ZoneNameserver, a nameserver within the zone needs an address,
+// either cfg.ZoneGlueAddress as glue or a discovered one
+func writeBINDZone(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	origin := strings.TrimSuffix(cfg.ZoneOrigin, ".") + "."
+	if "." == origin {
+		origin = "local."
+	}
+	if "" == cfg.ZoneNameserver {
+		return fmt.Errorf("output mode bind-zone requires --zone-nameserver")
+	}
+	nameserver := cfg.ZoneNameserver
+	if !strings.HasSuffix(nameserver, ".") {
+		nameserver += "." + origin
+	}
+	nsName := zoneName(nameserver, origin)
+
+	var glue string
+	if "" != cfg.ZoneGlueAddress {
+		record, ok := zoneAddressRecord(nsName, cfg.ZoneGlueAddress)
+		if !ok {
+			return fmt.Errorf("invalid nameserver address %s", cfg.ZoneGlueAddress)
+		}
+		glue = record
+	} else if !strings.HasSuffix(nsName, ".") {
+		found := false
+		for _, s := range discovered {
+			if zoneName(s.Hostname, origin) == nsName && net.ParseIP(s.Address) != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("nameserver %s was not discovered and needs --zone-nameserver-address", nameserver)
+		}
+	}
+
+	fmt.Fprintf(w, "$ORIGIN %s\n", origin)
+	fmt.Fprintln(w, "$TTL 3600")
+	fmt.Fprintf(w, "@\tIN\tSOA\t%s hostmaster.%s ( %d 3600 900 604800 60 )\n", nameserver, origin, time.Now().Unix())
+	fmt.Fprintf(w, "@\tIN\tNS\t%s\n", nameserver)
+
+	seen := make(map[string]bool)
+	if "" != glue {
+		seen[glue] = true
+		fmt.Fprintln(w, glue)
+	}
+	for _, s := range discovered {
+		name := zoneName(s.Hostname, origin)
+
+		record, ok := zoneAddressRecord(name, s.Address)
+		if !ok {
+			continue
+		}
+		if !seen[record] {
+			seen[record] = true
+			fmt.Fprintln(w, record)
+		}
+
+		if len(s.Text) == 0 {
+			continue
+		}
+		var txt []string
+		for _, t := range s.Text {
+			txt = append(txt, zoneTXTString(t))
+		}
+		record = fmt.Sprintf("%s\tIN\tTXT\t%s", name, strings.Join(txt, " "))
+		if !seen[record] {
+			seen[record] = true
+			fmt.Fprintln(w, record)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Report whether the nameserver of a zone file has an address record
+// when it is within the zone, as named-checkzone requires
+func zoneNameserverResolves(zone string, origin string) bool {
+	records := make(map[string]bool)
+	var nameserver string
+	for _, line := range strings.Split(zone, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 {
+			continue
+		}
+		switch fields[2] {
+		case "NS":
+			nameserver = fields[3]
+		case "A", "AAAA":
+			records[fields[0]] = true
+		}
+	}
+	name := zoneName(nameserver, origin)
+	return strings.HasSuffix(name, ".") || records[name]
+}
+
+func TestWriteBINDZoneNameserver(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+	}
+
+	for _, tc := range []struct {
+		nameserver string
+		glue       string
+		want       string
+	}{
+		{"ns", "192.168.1.1", "ns\tIN\tA\t192.168.1.1\n"},
+		{"ns.local.", "fd00::1", "ns\tIN\tAAAA\tfd00::1\n"},
+		{"host1", "", "host1\tIN\tA\t192.168.1.10\n"},
+		{"ns1.example.com.", "", "@\tIN\tNS\tns1.example.com.\n"},
+	} {
+		var buf bytes.Buffer
+		err := writeBINDZone(&buf, discovered, OutputConfig{ZoneOrigin: "local.", ZoneNameserver: tc.nameserver, ZoneGlueAddress: tc.glue})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.nameserver, err)
+		}
+		if !strings.Contains(buf.String(), tc.want) {
+			t.Errorf("%s: zone lacks %q:\n%s", tc.nameserver, tc.want, buf.String())
+		}
+		if !zoneNameserverResolves(buf.String(), "local.") {
+			t.Errorf("%s: nameserver without address record:\n%s", tc.nameserver, buf.String())
+		}
+	}
+
+	for _, cfg := range []OutputConfig{
+		{ZoneOrigin: "local."},
+		{ZoneOrigin: "local.", ZoneNameserver: "ns"},
+		{ZoneOrigin: "local.", ZoneNameserver: "ns", ZoneGlueAddress: "not-an-address"},
+	} {
+		err := writeBINDZone(&bytes.Buffer{}, discovered, cfg)
+		if err == nil {
+			t.Errorf("nameserver %q with address %q accepted", cfg.ZoneNameserver, cfg.ZoneGlueAddress)
+		}
+	}
+}
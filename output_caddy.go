@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Write one site block per HTTP(S) service type, <service>.localhost
+// reverse proxying to the addresses of all of its instances
+func writeCaddy(w io.Writer, discovered []Service) error {
+	var sites []string
+	upstreams := make(map[string][]string)
+	types, groups := groupByServiceType(discovered)
+	for _, serviceType := range types {
+		scheme := ""
+		switch serviceType {
+		case "_http._tcp":
+		case "_https._tcp":
+			scheme = "https://"
+		default:
+			continue
+		}
+
+		site := strings.TrimPrefix(strings.TrimSuffix(serviceType, "._tcp"), "_") + ".localhost"
+		sites = append(sites, site)
+		seen := make(map[string]bool)
+		for _, s := range groups[serviceType] {
+			upstream := scheme + net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+			if !seen[upstream] {
+				seen[upstream] = true
+				upstreams[site] = append(upstreams[site], upstream)
+			}
+		}
+	}
+
+	for i, site := range sites {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s {\n", site)
+		if len(upstreams[site]) > 1 {
+			fmt.Fprintf(w, "    reverse_proxy %s {\n", strings.Join(upstreams[site], " "))
+			fmt.Fprintln(w, "        lb_policy round_robin")
+			fmt.Fprintln(w, "    }")
+		} else {
+			fmt.Fprintf(w, "    reverse_proxy %s\n", upstreams[site][0])
+		}
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCaddyPerServiceType(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_https._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 443},
+		{ServiceType: "_http._tcp", Instance: "wiki", Hostname: "host2.local.", Address: "192.168.1.11", Port: 8080},
+		{ServiceType: "_ssh._tcp", Instance: "host1", Hostname: "host1.local.", Address: "192.168.1.10", Port: 22},
+	}
+
+	var buf bytes.Buffer
+	err := writeCaddy(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `http.localhost {
+    reverse_proxy 192.168.1.10:80 192.168.1.11:8080 {
+        lb_policy round_robin
+    }
+}
+
+https.localhost {
+    reverse_proxy https://192.168.1.10:443
+}
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
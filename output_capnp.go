@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	capnp "capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/encoding/text"
+	"capnproto.org/go/capnp/v3/schemas"
+
+	mdnscapnp "github.com/bbusse/mdns-discover/capnp/mdns"
+)
+
+// The text encoder looks the node of DiscoveryResult up in the registry
+func init() {
+	mdnscapnp.RegisterSchema(schemas.DefaultRegistry)
+}
+
+func capnpSortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Fill a Service struct of schema.capnp
+func capnpService(entry mdnscapnp.Service, s Service) error {
+	for _, set := range []struct {
+		set   func(string) error
+		value string
+	}{
+		{entry.SetServiceType, s.ServiceType},
+		{entry.SetInstance, s.Instance},
+		{entry.SetHostname, s.Hostname},
+		{entry.SetAddress, s.Address},
+		{entry.SetTxtEncoding, s.TxtEncoding},
+	} {
+		err := set.set(set.value)
+		if err != nil {
+			return err
+		}
+	}
+	entry.SetPort(uint16(s.Port))
+	entry.SetCached(s.Cached)
+
+	txt, err := entry.NewTxt(int32(len(s.Text)))
+	if err != nil {
+		return err
+	}
+	for i, record := range s.Text {
+		err = txt.Set(i, record)
+		if err != nil {
+			return err
+		}
+	}
+
+	keys := capnpSortedKeys(s.TxtMap)
+	txtMap, err := entry.NewTxtMap(int32(len(keys)))
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		err = txtMap.At(i).SetKey(key)
+		if err != nil {
+			return err
+		}
+		err = txtMap.At(i).SetValue(s.TxtMap[key])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build a DiscoveryResult message with the generated bindings
+func capnpMessage(discovered []Service) (*capnp.Message, mdnscapnp.DiscoveryResult, error) {
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, mdnscapnp.DiscoveryResult{}, err
+	}
+	result, err := mdnscapnp.NewRootDiscoveryResult(seg)
+	if err != nil {
+		return nil, result, err
+	}
+	services, err := result.NewServices(int32(len(discovered)))
+	if err != nil {
+		return nil, result, err
+	}
+	for i, s := range discovered {
+		err = capnpService(services.At(i), s)
+		if err != nil {
+			return nil, result, err
+		}
+	}
+	return msg, result, nil
+}
+
+// Write a packed DiscoveryResult message of schema.capnp, or its text
+// format with --capnp-text
+func writeCapnp(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	msg, result, err := capnpMessage(discovered)
+	if err != nil {
+		return err
+	}
+	if cfg.CapnpText {
+		data, err := text.Marshal(mdnscapnp.DiscoveryResult_TypeID, capnp.Struct(result))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, data)
+		return err
+	}
+	data, err := msg.MarshalPacked()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
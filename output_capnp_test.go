@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	capnp "capnproto.org/go/capnp/v3"
+
+	mdnscapnp "github.com/bbusse/mdns-discover/capnp/mdns"
+)
+
+func TestWriteCapnpRoundTrip(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+			Text: []string{"path=/", "weight=10"}, TxtMap: map[string]string{"path": "/", "weight": "10"}},
+		{ServiceType: "_ssh._tcp", Instance: "box", Hostname: "box.local.", Address: "fe80::1", Port: 22,
+			Text: []string{"a2V5PXZhbHVl"}, TxtEncoding: "base64", Cached: true},
+	}
+
+	var buf bytes.Buffer
+	err := writeCapnp(&buf, discovered, OutputConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := capnp.UnmarshalPacked(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := mdnscapnp.ReadRootDiscoveryResult(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	services, err := result.Services()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if services.Len() != len(discovered) {
+		t.Fatalf("got %d services, want %d", services.Len(), len(discovered))
+	}
+	for i, want := range discovered {
+		s := services.At(i)
+		got := Service{Port: int(s.Port()), Cached: s.Cached()}
+		got.ServiceType, _ = s.ServiceType()
+		got.Instance, _ = s.Instance()
+		got.Hostname, _ = s.Hostname()
+		got.Address, _ = s.Address()
+		got.TxtEncoding, _ = s.TxtEncoding()
+		txt, err := s.Txt()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < txt.Len(); j++ {
+			record, _ := txt.At(j)
+			got.Text = append(got.Text, record)
+		}
+		txtMap, err := s.TxtMap()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < txtMap.Len(); j++ {
+			if got.TxtMap == nil {
+				got.TxtMap = make(map[string]string)
+			}
+			key, _ := txtMap.At(j).Key()
+			got.TxtMap[key], _ = txtMap.At(j).Value()
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("service %d: got %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestWriteCapnpText(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+			Text: []string{"path=/"}, TxtMap: map[string]string{"path": "/"}},
+	}
+
+	var buf bytes.Buffer
+	err := writeCapnp(&buf, discovered, OutputConfig{CapnpText: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`serviceType = "_http._tcp"`,
+		`port = 80`,
+		`txt = ["path=/"]`,
+		`txtMap = [(key = "path", value = "/")]`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("text format %q lacks %q", buf.String(), want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Write services as a CBOR array, struct fields are keyed by their json names
+func writeCBOR(w io.Writer, discovered []Service) error {
+	return cbor.NewEncoder(w).Encode(discovered)
+}
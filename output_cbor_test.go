@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestWriteCBORRoundTrip(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+			Text: []string{"path=/", "weight=10"}, TxtMap: map[string]string{"path": "/", "weight": "10"}},
+		{ServiceType: "_ssh._tcp", Instance: "box", Hostname: "box.local.", Address: "fe80::1", Port: 22},
+	}
+
+	var buf bytes.Buffer
+	err := writeCBOR(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Service
+	err = cbor.Unmarshal(buf.Bytes(), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, discovered) {
+		t.Errorf("got %+v, want %+v", got, discovered)
+	}
+}
+
+func TestWriteCBORKeys(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeCBOR(&buf, []Service{{ServiceType: "_http._tcp", TxtMap: map[string]string{"path": "/"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var records []map[string]interface{}
+	err = cbor.Unmarshal(buf.Bytes(), &records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"service_type", "instance", "hostname", "address", "port", "txt", "txt_map"} {
+		if _, ok := records[0][key]; !ok {
+			t.Errorf("record %v lacks key %q", records[0], key)
+		}
+	}
+	txtMap, _ := records[0]["txt_map"].(map[interface{}]interface{})
+	if txtMap["path"] != "/" {
+		t.Errorf("txt_map is %#v", records[0]["txt_map"])
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Write a Checkmk local check line per service type, hostname and port,
+// the metric counts the addresses the service was found with
+func writeCheckMK(w io.Writer, discovered []Service) error {
+	var names []string
+	lines := make(map[string]Service)
+	addresses := make(map[string]int)
+	for _, s := range discovered {
+		name := strings.ReplaceAll("mdns_"+sanitizeName(s.ServiceType)+"_"+sanitizeName(shortHostname(s.Hostname))+
+			"_"+strconv.Itoa(s.Port), "-", "_")
+		if _, ok := lines[name]; !ok {
+			names = append(names, name)
+			lines[name] = s
+		}
+		addresses[name]++
+	}
+
+	for _, name := range names {
+		s := lines[name]
+		_, err := fmt.Fprintf(w, "0 %s addresses=%d %s:%d\n", name, addresses[name], strings.TrimSuffix(s.Hostname, "."), s.Port)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
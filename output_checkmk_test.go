@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCheckMKPerService(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "admin", Hostname: "host1.local.", Address: "192.168.1.10", Port: 8080},
+	}
+
+	var buf bytes.Buffer
+	err := writeCheckMK(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `0 mdns_http_tcp_host1_80 addresses=2 host1.local:80
+0 mdns_http_tcp_host1_8080 addresses=1 host1.local:8080
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type chefService struct {
+	Hostname    string   `json:"hostname"`
+	Address     string   `json:"address"`
+	Port        int      `json:"port"`
+	ServiceType string   `json:"service_type"`
+	Txt         []string `json:"txt"`
+}
+
+// Write a node attribute file for chef-client --json-attributes
+// with services keyed by buildKey under mdns.services
+func writeChef(w io.Writer, discovered []Service) error {
+	services := make(map[string]chefService, len(discovered))
+	for _, s := range discovered {
+		txt := s.Text
+		if txt == nil {
+			txt = []string{}
+		}
+		services[buildKey(s)] = chefService{
+			Hostname:    s.Hostname,
+			Address:     s.Address,
+			Port:        s.Port,
+			ServiceType: s.ServiceType,
+			Txt:         txt,
+		}
+	}
+
+	out, err := json.MarshalIndent(map[string]interface{}{
+		"mdns": map[string]interface{}{
+			"services": services,
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
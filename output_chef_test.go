@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Check the node attribute file against the attribute structure Ohai
+// and chef-client --json-attributes expect: nested objects keyed by
+// strings with mdns.services keyed by buildKey
+func TestWriteChefOhaiAttributes(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+			Text: []string{"path=/"}},
+		{ServiceType: "_ssh._tcp", Instance: "box", Hostname: "box.local.", Address: "fe80::1", Port: 22},
+	}
+
+	var buf bytes.Buffer
+	err := writeChef(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attributes map[string]map[string]map[string]map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &attributes)
+	if err != nil {
+		t.Fatalf("not a node attribute file: %v\n%s", err, buf.String())
+	}
+	if len(attributes) != 1 || len(attributes["mdns"]) != 1 {
+		t.Fatalf("want only mdns.services, got %s", buf.String())
+	}
+
+	services := attributes["mdns"]["services"]
+	if len(services) != len(discovered) {
+		t.Fatalf("got %d services, want %d", len(services), len(discovered))
+	}
+	for _, s := range discovered {
+		attribute, ok := services[buildKey(s)]
+		if !ok {
+			t.Errorf("no attribute %q", buildKey(s))
+			continue
+		}
+		txt := []interface{}{}
+		for _, record := range s.Text {
+			txt = append(txt, record)
+		}
+		want := map[string]interface{}{
+			"hostname":     s.Hostname,
+			"address":      s.Address,
+			"port":         float64(s.Port),
+			"service_type": s.ServiceType,
+			"txt":          txt,
+		}
+		if !reflect.DeepEqual(attribute, want) {
+			t.Errorf("%s: got %v, want %v", buildKey(s), attribute, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Addresses of an ip host entry accepted by IOS
+const ciscoMaxHostAddresses = 8
+
+// Write ip host and ipv6 host commands mapping each hostname to its
+// addresses, addresses beyond the eighth are left out
+func writeCiscoIOS(w io.Writer, discovered []Service) error {
+	var names []string
+	v4 := make(map[string][]string)
+	v6 := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		name := shortHostname(s.Hostname)
+		if ip == nil || seen[name+" "+s.Address] {
+			continue
+		}
+		seen[name+" "+s.Address] = true
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		if ip.To4() != nil {
+			v4[name] = append(v4[name], s.Address)
+		} else {
+			v6[name] = append(v6[name], s.Address)
+		}
+	}
+
+	for _, name := range names {
+		for _, entry := range []struct {
+			command   string
+			addresses []string
+		}{{"ip host", v4[name]}, {"ipv6 host", v6[name]}} {
+			if len(entry.addresses) == 0 {
+				continue
+			}
+			if len(entry.addresses) > ciscoMaxHostAddresses {
+				entry.addresses = entry.addresses[:ciscoMaxHostAddresses]
+			}
+			_, err := fmt.Fprintf(w, "%s %s %s\n", entry.command, name, strings.Join(entry.addresses, " "))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
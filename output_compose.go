@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type composeService struct {
+	Environment map[string]string `yaml:"environment"`
+	ExtraHosts  []string          `yaml:"extra_hosts"`
+}
+
+// Write a services block with one service per HTTP instance, its URL in
+// <SERVICE_TYPE>_URL and its hostname resolved through extra_hosts.
+// Services are named <instance>-<hostname> and the first address
+// of an instance is used for the URL
+func writeDockerCompose(w io.Writer, discovered []Service) error {
+	services := yaml.Node{Kind: yaml.MappingNode}
+	index := make(map[string]*composeService)
+	instances := make(map[string]string)
+	taken := make(map[string]int)
+	var names []string
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if "_http._tcp" != s.ServiceType {
+			continue
+		}
+		instance := s.Instance + "\x00" + s.Hostname
+		name, ok := instances[instance]
+		if !ok {
+			name = strings.ToLower(sanitizeName(shortHostname(s.Hostname)))
+			if "" != s.Instance {
+				name = strings.ToLower(sanitizeName(s.Instance)) + "-" + name
+			}
+			taken[name]++
+			if taken[name] > 1 {
+				name += "-" + strconv.Itoa(taken[name])
+			}
+			instances[instance] = name
+			index[name] = &composeService{Environment: map[string]string{
+				envName(s.ServiceType) + "_URL": "http://" + net.JoinHostPort(s.Address, strconv.Itoa(s.Port)),
+			}}
+			names = append(names, name)
+		}
+		c := index[name]
+
+		address := s.Address
+		if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+			address = "[" + address + "]"
+		}
+		entry := strings.TrimSuffix(s.Hostname, ".") + ":" + address
+		if !seen[name+" "+entry] {
+			seen[name+" "+entry] = true
+			c.ExtraHosts = append(c.ExtraHosts, entry)
+		}
+	}
+
+	for _, name := range names {
+		var value yaml.Node
+		err := value.Encode(index[name])
+		if err != nil {
+			return err
+		}
+		services.Content = append(services.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, &value)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(map[string]*yaml.Node{"services": &services})
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDockerComposePerInstance(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host2.local.", Address: "192.168.1.11", Port: 8080},
+	}
+
+	var buf bytes.Buffer
+	err := writeDockerCompose(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `services:
+  web-host1:
+    environment:
+      HTTP_TCP_URL: http://192.168.1.10:80
+    extra_hosts:
+      - host1.local:192.168.1.10
+      - host1.local:[fe80::1]
+  web-host2:
+    environment:
+      HTTP_TCP_URL: http://192.168.1.11:8080
+    extra_hosts:
+      - host2.local:192.168.1.11
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Write a CoreDNS hosts plugin block with one entry per address and hostname
+func writeCoreDNSHosts(w io.Writer, discovered []Service) error {
+	fmt.Fprintf(w, "# Generated by mdns-discover at %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintln(w, "hosts {")
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		entry := s.Address + " " + strings.TrimSuffix(s.Hostname, ".")
+		if seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		fmt.Fprintf(w, "    %s\n", entry)
+	}
+	fmt.Fprintln(w, "    fallthrough")
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
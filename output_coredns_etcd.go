@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type skydnsRecord struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+}
+
+var skydnsLabelReplacer = strings.NewReplacer(".", "-", ":", "-")
+
+// Build the SkyDNS path of a name, /skydns followed by its labels in reverse
+func skydnsPath(labels ...string) string {
+	var parts []string
+	for _, label := range labels {
+		parts = append(parts, strings.Split(strings.Trim(label, "."), ".")...)
+	}
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return "/skydns/" + strings.Join(parts, "/")
+}
+
+// Map each address and port to a SkyDNS record below
+// <hostname>.<service_type>.<origin>, so the CoreDNS etcd plugin
+// answers SRV queries for the service type
+func skydnsKeyValues(discovered []Service, origin string) (map[string]string, error) {
+	kvs := make(map[string]string, len(discovered))
+	for _, s := range discovered {
+		key := skydnsPath(s.ServiceType, origin) + "/" + shortHostname(s.Hostname) + "/" +
+			skydnsLabelReplacer.Replace(s.Address) + "-" + strconv.Itoa(s.Port)
+		value, err := json.Marshal(skydnsRecord{Host: s.Address, Port: s.Port, Priority: 10, Weight: 100})
+		if err != nil {
+			return nil, err
+		}
+		kvs[key] = string(value)
+	}
+	return kvs, nil
+}
+
+// Write etcdctl put commands loading the records below --zone-origin, or
+// put them with the etcd v3 client of etcdPut, shared with etcd-json,
+// when endpoints are given
+func writeCoreDNSEtcd(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	origin := cfg.ZoneOrigin
+	if "" == strings.Trim(origin, ".") {
+		origin = "local."
+	}
+	kvs, err := skydnsKeyValues(discovered, origin)
+	if err != nil {
+		return err
+	}
+
+	if "" != cfg.EtcdEndpoints {
+		return etcdPut(strings.Split(cfg.EtcdEndpoints, ","), kvs, cfg.EtcdTTL)
+	}
+
+	keys := make([]string, 0, len(kvs))
+	for key := range kvs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		_, err := fmt.Fprintf(w, "etcdctl put %s %s\n", shellQuote(key), shellQuote(kvs[key]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCoreDNSEtcdKeys(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "admin", Hostname: "host1.local.", Address: "192.168.1.10", Port: 8080},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+	}
+
+	for _, tc := range []struct {
+		origin string
+		want   string
+	}{
+		{"", `etcdctl put /skydns/local/_tcp/_http/host1/192-168-1-10-80 '{"host":"192.168.1.10","port":80,"priority":10,"weight":100}'
+etcdctl put /skydns/local/_tcp/_http/host1/192-168-1-10-8080 '{"host":"192.168.1.10","port":8080,"priority":10,"weight":100}'
+etcdctl put /skydns/local/_tcp/_http/host1/fe80--1-80 '{"host":"fe80::1","port":80,"priority":10,"weight":100}'
+`},
+		{"example.org.", `etcdctl put /skydns/org/example/_tcp/_http/host1/192-168-1-10-80 '{"host":"192.168.1.10","port":80,"priority":10,"weight":100}'
+etcdctl put /skydns/org/example/_tcp/_http/host1/192-168-1-10-8080 '{"host":"192.168.1.10","port":8080,"priority":10,"weight":100}'
+etcdctl put /skydns/org/example/_tcp/_http/host1/fe80--1-80 '{"host":"fe80::1","port":80,"priority":10,"weight":100}'
+`},
+	} {
+		var buf bytes.Buffer
+		err := writeCoreDNSEtcd(&buf, discovered, OutputConfig{ZoneOrigin: tc.origin})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("origin %q: got\n%s\nwant\n%s", tc.origin, buf.String(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Service types of DNS resolvers
+var dnsServiceTypes = map[string]bool{
+	"_dns._udp":          true,
+	"_dns._tcp":          true,
+	"_mdnsrepeater._udp": true,
+	"_dns-sd._udp":       true,
+}
+
+// Write a Corefile server block forwarding all queries to the discovered resolvers
+func writeCoreDNSForward(w io.Writer, discovered []Service) error {
+	fmt.Fprintf(w, "# Generated by mdns-discover at %s\n", time.Now().Format(time.RFC3339))
+
+	var upstreams []string
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if !dnsServiceTypes[s.ServiceType] {
+			continue
+		}
+		upstream := net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+		if seen[upstream] {
+			continue
+		}
+		seen[upstream] = true
+		upstreams = append(upstreams, upstream)
+	}
+	if len(upstreams) == 0 {
+		_, err := fmt.Fprintln(w, "# No DNS resolvers discovered")
+		return err
+	}
+
+	fmt.Fprintln(w, ".:53 {")
+	fmt.Fprint(w, "    forward .")
+	for _, upstream := range upstreams {
+		fmt.Fprintf(w, " %s", upstream)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    health")
+	fmt.Fprintln(w, "    log")
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
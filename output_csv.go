@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"log"
+	"strings"
+)
+
+// Parse comma separated field=alias pairs, unknown fields are
+// reported and skipped
+func parseColumnAliases(value string) map[string]string {
+	aliases := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if "" == strings.TrimSpace(pair) {
+			continue
+		}
+		field, alias, ok := strings.Cut(pair, "=")
+		field = strings.TrimSpace(field)
+		if !ok || "" == alias {
+			log.Println("Warning: ignoring column name without alias:", pair)
+			continue
+		}
+		if !validOutputField(field) {
+			log.Println("Warning: ignoring column name for unknown field:", field)
+			continue
+		}
+		aliases[field] = alias
+	}
+	return aliases
+}
+
+// Write a header and one record per address, separated by comma
+func writeCSV(w io.Writer, discovered []Service, cfg OutputConfig, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	header := make([]string, 0, len(outputFields))
+	for _, field := range outputFields {
+		name := field
+		if alias, ok := cfg.ColumnAliases[field]; ok {
+			name = alias
+		}
+		header = append(header, name)
+	}
+	cw.Write(header)
+
+	for _, s := range discovered {
+		record := make([]string, 0, len(outputFields))
+		for _, field := range outputFields {
+			record = append(record, fieldValue(s, field))
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+// Write an address directive per hostname and IPv4 address,
+// or IPv6 address with --ip-version=6
+func writeDnsmasq(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil || (ip.To4() == nil) != ("6" == cfg.IPVersion) {
+			continue
+		}
+		line := fmt.Sprintf("address=/%s/%s", shortHostname(s.Hostname), s.Address)
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Write an extra_hosts list with one "<hostname>:<address>" entry per
+// hostname and address, IPv6 addresses are enclosed in brackets
+func writeDockerExtraHosts(w io.Writer, discovered []Service) error {
+	fmt.Fprintln(w, "extra_hosts:")
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		address := s.Address
+		if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+			address = "[" + address + "]"
+		}
+		entry := strings.TrimSuffix(s.Hostname, ".") + ":" + address
+		if seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		_, err := fmt.Fprintf(w, "  - %s\n", strconv.Quote(entry))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Quote values that a dotenv parser would split or unescape
+func dotenvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\"'#$\\`") {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(value) + `"`
+}
+
+// Write MDNS_<SERVICE_TYPE>=<address>:<port> per service instance, several
+// instances of a type are numbered MDNS_<SERVICE_TYPE>_1, MDNS_<SERVICE_TYPE>_2.
+// An instance with several addresses also gets every address as
+// MDNS_<SERVICE_TYPE>_ADDR_1, MDNS_<SERVICE_TYPE>_ADDR_2
+func writeEnvFile(w io.Writer, discovered []Service) error {
+	for _, instance := range envInstances(discovered) {
+		name := "MDNS_" + strings.TrimPrefix(instance.Name, "_")
+		port := strconv.Itoa(instance.Service.Port)
+		_, err := fmt.Fprintf(w, "%s=%s\n", name, dotenvValue(net.JoinHostPort(instance.Service.Address, port)))
+		if err != nil {
+			return err
+		}
+		if len(instance.Addresses) < 2 {
+			continue
+		}
+		for i, address := range instance.Addresses {
+			_, err = fmt.Fprintf(w, "%s_ADDR_%d=%s\n", name, i+1, dotenvValue(net.JoinHostPort(address, port)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteEnvFileEveryAddress(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeEnvFile(&buf, envTestServices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `MDNS_HTTP_TCP_1=192.168.1.10:80
+MDNS_HTTP_TCP_1_ADDR_1=192.168.1.10:80
+MDNS_HTTP_TCP_1_ADDR_2=[fe80::1]:80
+MDNS_HTTP_TCP_2=192.168.1.11:8080
+MDNS_SSH_TCP=192.168.1.12:22
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
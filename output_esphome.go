@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Service type of the ESPHome native API
+const esphomeServiceType = "_esphomelib._tcp"
+
+// Write a configuration skeleton per ESPHome device as separate YAML
+// documents. The platform and board TXT records select the platform block,
+// manual_ip keeps the discovered address with placeholders for the gateway
+// and subnet, which mDNS does not announce, and api.host the address too
+func writeESPHome(w io.Writer, discovered []Service) error {
+	var names []string
+	devices := make(map[string]Service)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if esphomeServiceType != s.ServiceType || ip == nil || ip.To4() == nil {
+			continue
+		}
+		name := strings.ToLower(sanitizeName(shortHostname(s.Hostname)))
+		if _, ok := devices[name]; !ok {
+			names = append(names, name)
+			devices[name] = s
+		}
+	}
+	if len(names) == 0 {
+		_, err := fmt.Fprintln(w, "# No ESPHome devices discovered")
+		return err
+	}
+
+	for i, name := range names {
+		s := devices[name]
+		txt := parseTxt(s.Text)
+		ip := net.ParseIP(s.Address).To4()
+
+		if i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		fmt.Fprintf(w, "# %s.yaml\n", name)
+		if "" != txt["version"] {
+			fmt.Fprintf(w, "# Discovered running ESPHome %s\n", strings.Join(strings.Fields(txt["version"]), " "))
+		}
+		fmt.Fprintln(w, "esphome:")
+		fmt.Fprintf(w, "  name: %s\n", name)
+		if platform := strings.ToLower(txt["platform"]); "" != platform {
+			fmt.Fprintf(w, "%s:\n", sanitizeName(platform))
+			if "" != txt["board"] {
+				fmt.Fprintf(w, "  board: %q\n", txt["board"])
+			}
+		}
+		fmt.Fprintln(w, "wifi:")
+		fmt.Fprintln(w, "  ssid: !secret wifi_ssid")
+		fmt.Fprintln(w, "  password: !secret wifi_password")
+		fmt.Fprintln(w, "  manual_ip:")
+		fmt.Fprintf(w, "    static_ip: %s\n", ip)
+		fmt.Fprintln(w, "    # gateway: <gateway>")
+		fmt.Fprintln(w, "    # subnet: <subnet>")
+		fmt.Fprintln(w, "api:")
+		fmt.Fprintf(w, "  host: %s\n", ip)
+		_, err := fmt.Fprintf(w, "  port: %d\n", s.Port)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteESPHome(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_esphomelib._tcp", Instance: "kitchen", Hostname: "kitchen-plug.local.", Address: "192.168.1.9",
+			Port: 6053, Text: []string{"version=2024.6.1", "platform=ESP32", "board=esp32dev"}},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "web.local.", Address: "192.168.1.10", Port: 80},
+	}
+
+	var buf bytes.Buffer
+	err := writeESPHome(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# kitchen-plug.yaml
+# Discovered running ESPHome 2024.6.1
+esphome:
+  name: kitchen-plug
+esp32:
+  board: "esp32dev"
+wifi:
+  ssid: !secret wifi_ssid
+  password: !secret wifi_password
+  manual_ip:
+    static_ip: 192.168.1.9
+    # gateway: <gateway>
+    # subnet: <subnet>
+api:
+  host: 192.168.1.9
+  port: 6053
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
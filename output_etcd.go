@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type etcdValue struct {
+	Port int      `json:"port"`
+	Txt  []string `json:"txt"`
+}
+
+// Map /mdns/<service_type>/<hostname>/<address>:<port> keys to port and TXT records
+func etcdKeyValues(discovered []Service) (map[string]string, error) {
+	kvs := make(map[string]string, len(discovered))
+	for _, s := range discovered {
+		key := "/mdns/" + buildKey(s)
+		value, err := json.Marshal(etcdValue{Port: s.Port, Txt: s.Text})
+		if err != nil {
+			return nil, err
+		}
+		kvs[key] = string(value)
+	}
+	return kvs, nil
+}
+
+func writeEtcd(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	kvs, err := etcdKeyValues(discovered)
+	if err != nil {
+		return err
+	}
+
+	if "" != cfg.EtcdEndpoints {
+		return etcdPut(strings.Split(cfg.EtcdEndpoints, ","), kvs, cfg.EtcdTTL)
+	}
+
+	values := make(map[string]json.RawMessage, len(kvs))
+	for key, value := range kvs {
+		values[key] = json.RawMessage(value)
+	}
+	out, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
+
+// Write keys with the etcd v3 client, attached to a lease when a TTL is given
+func etcdPut(endpoints []string, kvs map[string]string, ttl time.Duration) error {
+	var urls []string
+	secure := false
+	for _, endpoint := range endpoints {
+		endpoint = strings.TrimSuffix(strings.TrimSpace(endpoint), "/")
+		if "" == endpoint {
+			continue
+		}
+		urls = append(urls, endpoint)
+		secure = secure || strings.HasPrefix(endpoint, "https://")
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("no etcd endpoint given")
+	}
+
+	cfg := clientv3.Config{Endpoints: urls, DialTimeout: 10 * time.Second}
+	if secure {
+		cfg.TLS = &tls.Config{}
+	}
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var opts []clientv3.OpOption
+	if ttl > 0 {
+		lease, err := client.Grant(ctx, int64(ttl.Seconds()))
+		if err != nil {
+			return err
+		}
+		opts = append(opts, clientv3.WithLease(lease.ID))
+	}
+
+	keys := make([]string, 0, len(kvs))
+	for key := range kvs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		_, err := client.Put(ctx, key, kvs[key], opts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEtcdKeyValuesPerPort(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "admin", Hostname: "host1.local.", Address: "192.168.1.10", Port: 8080},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+	}
+
+	kvs, err := etcdKeyValues(discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"/mdns/_http._tcp/host1.local/192.168.1.10:80":   `{"port":80,"txt":null}`,
+		"/mdns/_http._tcp/host1.local/192.168.1.10:8080": `{"port":8080,"txt":null}`,
+		"/mdns/_http._tcp/host1.local/[fe80::1]:80":      `{"port":80,"txt":null}`,
+	}
+	if !reflect.DeepEqual(kvs, want) {
+		t.Errorf("got %v, want %v", kvs, want)
+	}
+}
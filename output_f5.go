@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// Write an internal string data group per service type with a record
+// per address keyed by buildKey, for tmsh load sys config merge
+func writeF5iRule(w io.Writer, discovered []Service) error {
+	types, groups := groupByServiceType(discovered)
+	for i, t := range types {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "ltm data-group internal mdns-%s {\n", sanitizeName(t))
+		fmt.Fprintln(w, "    records {")
+		seen := make(map[string]bool)
+		for _, s := range groups[t] {
+			key := buildKey(s)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			fmt.Fprintf(w, "        %q {\n", key)
+			fmt.Fprintf(w, "            data %q\n", net.JoinHostPort(s.Address, strconv.Itoa(s.Port)))
+			fmt.Fprintln(w, "        }")
+		}
+		fmt.Fprintln(w, "    }")
+		fmt.Fprintln(w, "    type string")
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
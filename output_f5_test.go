@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteF5iRuleKeepsEveryAddress(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "admin", Hostname: "host1.local.", Address: "192.168.1.10", Port: 8080},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+	}
+
+	var buf bytes.Buffer
+	err := writeF5iRule(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `ltm data-group internal mdns-http-tcp {
+    records {
+        "_http._tcp/host1.local/192.168.1.10:80" {
+            data "192.168.1.10:80"
+        }
+        "_http._tcp/host1.local/[fe80::1]:80" {
+            data "[fe80::1]:80"
+        }
+        "_http._tcp/host1.local/192.168.1.10:8080" {
+            data "192.168.1.10:8080"
+        }
+    }
+    type string
+}
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"sort"
+
+	"github.com/bbusse/mdns-discover/fbs/mdns"
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// Build a vector of offsets, flatbuffers are built back to front
+func flatbuffersVector(b *flatbuffers.Builder, start func(*flatbuffers.Builder, int) flatbuffers.UOffsetT,
+	offsets []flatbuffers.UOffsetT) flatbuffers.UOffsetT {
+	start(b, len(offsets))
+	for i := len(offsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(offsets[i])
+	}
+	return b.EndVector(len(offsets))
+}
+
+// Serialize a service; strings and vectors have to be created
+// before the table is started
+func flatbuffersService(b *flatbuffers.Builder, s Service) flatbuffers.UOffsetT {
+	serviceType := b.CreateString(s.ServiceType)
+	instance := b.CreateString(s.Instance)
+	hostname := b.CreateString(s.Hostname)
+	address := b.CreateString(s.Address)
+	txtEncoding := b.CreateString(s.TxtEncoding)
+
+	records := make([]flatbuffers.UOffsetT, len(s.Text))
+	for i, record := range s.Text {
+		records[i] = b.CreateString(record)
+	}
+	txt := flatbuffersVector(b, mdns.ServiceStartTxtVector, records)
+
+	keys := make([]string, 0, len(s.TxtMap))
+	for key := range s.TxtMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]flatbuffers.UOffsetT, len(keys))
+	for i, key := range keys {
+		k := b.CreateString(key)
+		v := b.CreateString(s.TxtMap[key])
+		mdns.TxtEntryStart(b)
+		mdns.TxtEntryAddKey(b, k)
+		mdns.TxtEntryAddValue(b, v)
+		entries[i] = mdns.TxtEntryEnd(b)
+	}
+	txtMap := flatbuffersVector(b, mdns.ServiceStartTxtMapVector, entries)
+
+	mdns.ServiceStart(b)
+	mdns.ServiceAddServiceType(b, serviceType)
+	mdns.ServiceAddInstance(b, instance)
+	mdns.ServiceAddHostname(b, hostname)
+	mdns.ServiceAddAddress(b, address)
+	mdns.ServiceAddPort(b, int32(s.Port))
+	mdns.ServiceAddTxt(b, txt)
+	mdns.ServiceAddTxtMap(b, txtMap)
+	mdns.ServiceAddTxtEncoding(b, txtEncoding)
+	mdns.ServiceAddCached(b, s.Cached)
+	return mdns.ServiceEnd(b)
+}
+
+// Write services as a ServiceList table of fbs/Service.fbs
+func writeFlatBuffers(w io.Writer, discovered []Service) error {
+	b := flatbuffers.NewBuilder(1024)
+	services := make([]flatbuffers.UOffsetT, len(discovered))
+	for i, s := range discovered {
+		services[i] = flatbuffersService(b, s)
+	}
+	vector := flatbuffersVector(b, mdns.ServiceListStartServicesVector, services)
+
+	mdns.ServiceListStart(b)
+	mdns.ServiceListAddServices(b, vector)
+	mdns.FinishServiceListBuffer(b, mdns.ServiceListEnd(b))
+
+	_, err := w.Write(b.FinishedBytes())
+	return err
+}
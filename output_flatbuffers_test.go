@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/bbusse/mdns-discover/fbs/mdns"
+)
+
+func TestWriteFlatBuffersRoundTrip(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+			Text: []string{"path=/", "weight=10"}, TxtMap: map[string]string{"path": "/", "weight": "10"}},
+		{ServiceType: "_ssh._tcp", Instance: "box", Hostname: "box.local.", Address: "fe80::1", Port: 22,
+			Text: []string{"a2V5PXZhbHVl"}, TxtEncoding: "base64", Cached: true},
+	}
+
+	var buf bytes.Buffer
+	err := writeFlatBuffers(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := mdns.GetRootAsServiceList(buf.Bytes(), 0)
+	if list.ServicesLength() != len(discovered) {
+		t.Fatalf("got %d services, want %d", list.ServicesLength(), len(discovered))
+	}
+	for i, want := range discovered {
+		var s mdns.Service
+		if !list.Services(&s, i) {
+			t.Fatalf("service %d missing", i)
+		}
+		got := Service{
+			ServiceType: string(s.ServiceType()),
+			Instance:    string(s.Instance()),
+			Hostname:    string(s.Hostname()),
+			Address:     string(s.Address()),
+			Port:        int(s.Port()),
+			TxtEncoding: string(s.TxtEncoding()),
+			Cached:      s.Cached(),
+		}
+		for j := 0; j < s.TxtLength(); j++ {
+			got.Text = append(got.Text, string(s.Txt(j)))
+		}
+		var entry mdns.TxtEntry
+		for j := 0; j < s.TxtMapLength(); j++ {
+			s.TxtMap(&entry, j)
+			if got.TxtMap == nil {
+				got.TxtMap = make(map[string]string)
+			}
+			got.TxtMap[string(entry.Key())] = string(entry.Value())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("service %d: got %+v, want %+v", i, got, want)
+		}
+	}
+}
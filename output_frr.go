@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Write a router bgp block with an external neighbor per unique address
+func writeFRR(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	fmt.Fprintf(w, "router bgp %d\n", cfg.FRRASN)
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if seen[s.Address] {
+			continue
+		}
+		seen[s.Address] = true
+		fmt.Fprintf(w, " neighbor %s remote-as external\n", s.Address)
+		fmt.Fprintf(w, " neighbor %s description %s\n", s.Address, strings.TrimSuffix(s.Hostname, "."))
+	}
+	_, err := fmt.Fprintln(w, "exit")
+	return err
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+type gelfMessage struct {
+	Version      string  `json:"version"`
+	Host         string  `json:"host"`
+	ShortMessage string  `json:"short_message"`
+	Timestamp    float64 `json:"timestamp"`
+	ServiceType  string  `json:"_mdns_service_type"`
+	Instance     string  `json:"_mdns_instance"`
+	Hostname     string  `json:"_mdns_hostname"`
+	Address      string  `json:"_mdns_address"`
+	Port         int     `json:"_mdns_port"`
+}
+
+// Write one GELF 1.1 message per address, as JSON lines or, with an
+// address, as uncompressed GELF UDP datagrams
+func writeGELF(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	host, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	var conn net.Conn
+	if "" != cfg.GELFAddr {
+		conn, err = net.Dial("udp", cfg.GELFAddr)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+	}
+
+	timestamp := float64(time.Now().UnixNano()/int64(time.Millisecond)) / 1000
+	for _, s := range discovered {
+		msg, err := json.Marshal(gelfMessage{
+			Version:      "1.1",
+			Host:         host,
+			ShortMessage: s.ServiceType + " discovered",
+			Timestamp:    timestamp,
+			ServiceType:  s.ServiceType,
+			Instance:     s.Instance,
+			Hostname:     s.Hostname,
+			Address:      s.Address,
+			Port:         s.Port,
+		})
+		if err != nil {
+			return err
+		}
+		// Each datagram carries a single message without a delimiter
+		if conn != nil {
+			_, err = conn.Write(msg)
+		} else {
+			_, err = fmt.Fprintf(w, "%s\n", msg)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
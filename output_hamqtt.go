@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type haDiscoveryConfig struct {
+	Name          string   `json:"name"`
+	UniqueID      string   `json:"unique_id"`
+	StateTopic    string   `json:"state_topic"`
+	ValueTemplate string   `json:"value_template"`
+	Device        haDevice `json:"device"`
+}
+
+type haDevice struct {
+	Identifiers []string `json:"identifiers"`
+	Name        string   `json:"name"`
+}
+
+// Build a retained sensor config per hostname and service type, the sensor
+// state is the address published by the mqtt output at mdns/<service_type>/<hostname>
+func haDiscoveryMessages(discovered []Service) ([]mqttMessage, error) {
+	var messages []mqttMessage
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		host := strings.ReplaceAll(sanitizeName(shortHostname(s.Hostname)), "-", "_")
+		id := host + "_" + strings.ReplaceAll(sanitizeName(s.ServiceType), "-", "_")
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		payload, err := json.Marshal(haDiscoveryConfig{
+			Name:          s.Instance + " " + s.ServiceType,
+			UniqueID:      "mdns_discover_" + id,
+			StateTopic:    mqttServiceTopic(s),
+			ValueTemplate: "{{ value_json.address }}",
+			Device: haDevice{
+				Identifiers: []string{"mdns_discover_" + host},
+				Name:        shortHostname(s.Hostname),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, mqttMessage{
+			Topic:   "homeassistant/sensor/" + id + "/config",
+			Payload: payload,
+			Retain:  true,
+		})
+	}
+	return messages, nil
+}
+
+// Write Home Assistant MQTT discovery configs followed by the sensor states
+func writeHAMQTTDiscovery(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	messages, err := haDiscoveryMessages(discovered)
+	if err != nil {
+		return err
+	}
+	states, err := mqttMessages(discovered)
+	if err != nil {
+		return err
+	}
+	messages = append(messages, states...)
+
+	if "" != cfg.MQTTBroker {
+		return publishMQTT(cfg.MQTTBroker, messages)
+	}
+
+	for _, m := range messages {
+		_, err := fmt.Fprintf(w, "%s\t%s\n", m.Topic, m.Payload)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
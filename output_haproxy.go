@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+)
+
+// TXT record keys passed on as HAProxy server parameters,
+// values that aren't non-negative integers are left out
+var haproxyServerParams = []string{"weight", "maxconn"}
+
+// Write one backend block per service type with a server line per address
+func writeHAProxy(w io.Writer, discovered []Service) error {
+	types, groups := groupByServiceType(discovered)
+	for i, serviceType := range types {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "backend %s\n", sanitizeName(serviceType))
+
+		names := make(map[string]int)
+		for _, s := range groups[serviceType] {
+			name := sanitizeName(s.Hostname)
+			names[name]++
+			if names[name] > 1 {
+				name += "-" + strconv.Itoa(names[name])
+			}
+
+			line := fmt.Sprintf("    server %s %s check", name, net.JoinHostPort(s.Address, strconv.Itoa(s.Port)))
+			for _, param := range haproxyServerParams {
+				value, ok := s.TxtMap[param]
+				if !ok || "" == value {
+					continue
+				}
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 0 {
+					log.Printf("Warning: ignoring invalid %s %q of %s\n", param, value, s.Hostname)
+					continue
+				}
+				line += " " + param + " " + strconv.Itoa(n)
+			}
+			_, err := fmt.Fprintln(w, line)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
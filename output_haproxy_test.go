@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteHAProxyServerParams(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Hostname: "web1.local.", Address: "192.168.1.10", Port: 80,
+			TxtMap: map[string]string{"weight": "10", "maxconn": "100"}},
+		{ServiceType: "_http._tcp", Hostname: "web2.local.", Address: "192.168.1.11", Port: 80,
+			TxtMap: map[string]string{"weight": "10 backup", "maxconn": "1\n    option httpchk"}},
+		{ServiceType: "_http._tcp", Hostname: "web3.local.", Address: "192.168.1.12", Port: 80,
+			TxtMap: map[string]string{"weight": "-1", "maxconn": ""}},
+	}
+
+	var buf bytes.Buffer
+	err := writeHAProxy(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `backend http-tcp
+    server web1-local 192.168.1.10:80 check weight 10 maxconn 100
+    server web2-local 192.168.1.11:80 check
+    server web3-local 192.168.1.12:80 check
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Address of a service in YAML configuration management outputs
+type yamlService struct {
+	Hostname string `yaml:"hostname"`
+	Address  string `yaml:"address"`
+	Port     int    `yaml:"port"`
+}
+
+// Write a Hiera data document with services grouped by type
+// under the mdns_discover::services key
+func writeHiera(w io.Writer, discovered []Service) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(map[string]interface{}{
+		"mdns_discover::services": yamlServicesByType(discovered),
+	})
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func yamlServicesByType(discovered []Service) map[string][]yamlService {
+	services := make(map[string][]yamlService)
+	for _, s := range discovered {
+		services[s.ServiceType] = append(services[s.ServiceType], yamlService{
+			Hostname: s.Hostname,
+			Address:  s.Address,
+			Port:     s.Port,
+		})
+	}
+	return services
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"io"
+	"net"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type homeAssistantDevice struct {
+	Name     string `yaml:"name"`
+	MAC      string `yaml:"mac,omitempty"`
+	Hostname string `yaml:"hostname"`
+	IP       string `yaml:"ip"`
+	Track    bool   `yaml:"track"`
+}
+
+// Write a known_devices.yaml entry per hostname, keyed by the hostname as
+// Home Assistant object ID. MAC addresses are looked up in the ARP table,
+// manufacturer and model TXT records are appended to the friendly name
+func writeHomeAssistant(w io.Writer, discovered []Service) error {
+	var ids, addresses []string
+	devices := make(map[string]*homeAssistantDevice)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil {
+			continue
+		}
+		name := shortHostname(s.Hostname)
+		id := strings.ToLower(strings.ReplaceAll(sanitizeName(name), "-", "_"))
+		d, ok := devices[id]
+		if !ok {
+			d = &homeAssistantDevice{Name: name, Hostname: strings.TrimSuffix(s.Hostname, "."), IP: s.Address, Track: true}
+			devices[id] = d
+			ids = append(ids, id)
+		} else if ip.To4() != nil && net.ParseIP(d.IP).To4() == nil {
+			// Prefer IPv4, it is the only family with ARP entries
+			d.IP = s.Address
+		}
+
+		txt := parseTxt(s.Text)
+		if vendor := strings.TrimSpace(txt["manufacturer"] + " " + txt["model"]); "" != vendor && name == d.Name {
+			d.Name = name + " (" + vendor + ")"
+		}
+		addresses = append(addresses, s.Address)
+	}
+
+	macs := lookupMACs(addresses)
+	doc := yaml.Node{Kind: yaml.MappingNode}
+	for _, id := range ids {
+		d := devices[id]
+		if mac, ok := macs[d.IP]; ok {
+			d.MAC = strings.ToUpper(mac.String())
+		}
+		var value yaml.Node
+		err := value.Encode(d)
+		if err != nil {
+			return err
+		}
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: id}, &value)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(&doc)
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
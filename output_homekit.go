@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Service type of HomeKit accessories
+const homekitServiceType = "_hap._tcp"
+
+// Accessory type of the generated entries, to be replaced with the type
+// registered by the Homebridge plugin controlling the accessory
+const homebridgeAccessory = "HAP"
+
+// Build a Homebridge accessory per HomeKit instance. The HAP TXT records
+// id, md and sf become deviceId, model and statusFlags, other records are
+// kept with their key, e.g. ci for the category
+func homebridgeAccessories(discovered []Service) []map[string]interface{} {
+	accessories := []map[string]interface{}{}
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if homekitServiceType != s.ServiceType || seen[s.Instance] {
+			continue
+		}
+		seen[s.Instance] = true
+
+		txt := parseTxt(s.Text)
+		accessory := map[string]interface{}{}
+		for key, value := range txt {
+			if "id" != key && "md" != key && "sf" != key {
+				accessory[key] = value
+			}
+		}
+		if id, ok := txt["id"]; ok {
+			accessory["deviceId"] = id
+		}
+		if md, ok := txt["md"]; ok {
+			accessory["model"] = md
+		}
+		if sf, ok := txt["sf"]; ok {
+			// Bit 0 is set while the accessory is not paired
+			if flags, err := strconv.Atoi(sf); err == nil {
+				accessory["statusFlags"] = flags
+				accessory["paired"] = flags&1 == 0
+			} else {
+				accessory["sf"] = sf
+			}
+		}
+		accessory["accessory"] = homebridgeAccessory
+		accessory["name"] = s.Instance
+		accessory["host"] = s.Address
+		accessory["port"] = s.Port
+		accessories = append(accessories, accessory)
+	}
+	return accessories
+}
+
+// Write a Homebridge config.json fragment with an accessories array
+func writeHomeKit(w io.Writer, discovered []Service) error {
+	out, err := json.MarshalIndent(map[string]interface{}{
+		"accessories": homebridgeAccessories(discovered),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
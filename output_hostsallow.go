@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+// Write hosts.allow rules for every address, grouped by service type.
+// An address is only listed under the first service type it was found with
+func writeHostsAllow(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	daemon := cfg.TCPDaemon
+	if "" == daemon {
+		daemon = "ALL"
+	}
+
+	seen := make(map[string]bool)
+	types, groups := groupByServiceType(discovered)
+	for _, serviceType := range types {
+		var addresses []string
+		for _, s := range groups[serviceType] {
+			if seen[s.Address] {
+				continue
+			}
+			seen[s.Address] = true
+			address := s.Address
+			if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+				address = "[" + address + "]"
+			}
+			addresses = append(addresses, address)
+		}
+		if len(addresses) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "# %s\n", serviceType)
+		for _, address := range addresses {
+			_, err := fmt.Fprintf(w, "%s: %s\n", daemon, address)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
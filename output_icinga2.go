@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+var icingaStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func icingaString(s string) string {
+	return `"` + icingaStringEscaper.Replace(s) + `"`
+}
+
+type icingaHost struct {
+	name     string
+	address  string
+	address6 string
+	types    []string
+	ports    map[string]int
+}
+
+// Write an object Host per hostname and an apply Service rule
+// per service type assigned to the hosts offering it
+func writeIcinga2(w io.Writer, discovered []Service) error {
+	var hosts []*icingaHost
+	index := make(map[string]*icingaHost)
+	for _, s := range discovered {
+		name := shortHostname(s.Hostname)
+		h, ok := index[name]
+		if !ok {
+			h = &icingaHost{name: name, ports: make(map[string]int)}
+			index[name] = h
+			hosts = append(hosts, h)
+		}
+		if ip := net.ParseIP(s.Address); ip != nil {
+			if ip.To4() != nil && "" == h.address {
+				h.address = s.Address
+			} else if ip.To4() == nil && "" == h.address6 {
+				h.address6 = s.Address
+			}
+		}
+		if _, ok := h.ports[s.ServiceType]; !ok {
+			h.types = append(h.types, s.ServiceType)
+			h.ports[s.ServiceType] = s.Port
+		}
+	}
+
+	for _, h := range hosts {
+		fmt.Fprintf(w, "object Host %s {\n", icingaString(h.name))
+		fmt.Fprintln(w, "  check_command = \"hostalive\"")
+		if "" != h.address {
+			fmt.Fprintf(w, "  address = %s\n", icingaString(h.address))
+		}
+		if "" != h.address6 {
+			fmt.Fprintf(w, "  address6 = %s\n", icingaString(h.address6))
+		}
+		var types []string
+		for _, t := range h.types {
+			types = append(types, icingaString(t))
+		}
+		fmt.Fprintf(w, "  vars.mdns_services = [ %s ]\n", strings.Join(types, ", "))
+		fmt.Fprintln(w, "  vars.mdns_ports = {")
+		for _, t := range h.types {
+			fmt.Fprintf(w, "    %s = %d\n", icingaString(t), h.ports[t])
+		}
+		fmt.Fprintln(w, "  }")
+		fmt.Fprintln(w, "}")
+		fmt.Fprintln(w)
+	}
+
+	types, _ := groupByServiceType(discovered)
+	for i, t := range types {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		command, portVar := "tcp", "tcp_port"
+		if strings.HasSuffix(t, "._udp") {
+			command, portVar = "udp", "udp_port"
+		}
+		fmt.Fprintf(w, "apply Service %s {\n", icingaString("mdns-"+sanitizeName(t)))
+		fmt.Fprintf(w, "  check_command = %s\n", icingaString(command))
+		fmt.Fprintf(w, "  vars.%s = host.vars.mdns_ports[%s]\n", portVar, icingaString(t))
+		fmt.Fprintf(w, "  assign where %s in host.vars.mdns_services\n", icingaString(t))
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
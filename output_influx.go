@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	influxTagEscaper    = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+	influxStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+// Write one InfluxDB line protocol point per address
+func writeInflux(w io.Writer, discovered []Service) error {
+	timestamp := time.Now().UnixNano()
+	for _, s := range discovered {
+		_, err := fmt.Fprintf(w, "mdns_service,service_type=%s,hostname=%s address=\"%s\",port=%di,txt=\"%s\" %d\n",
+			influxTagEscaper.Replace(s.ServiceType),
+			influxTagEscaper.Replace(s.Hostname),
+			influxStringEscaper.Replace(s.Address),
+			s.Port,
+			influxStringEscaper.Replace(strings.Join(s.Text, " ")),
+			timestamp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
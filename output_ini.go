@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Write an INI section per service type with hostname<n>, address<n> and
+// port<n> keys per address, numbered from 1
+func writeINI(w io.Writer, discovered []Service) error {
+	types, groups := groupByServiceType(discovered)
+	for i, t := range types {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "[%s]\n", t)
+		for n, s := range groups[t] {
+			fmt.Fprintf(w, "hostname%d=%s\n", n+1, strings.TrimSuffix(s.Hostname, "."))
+			fmt.Fprintf(w, "address%d=%s\n", n+1, s.Address)
+			_, err := fmt.Fprintf(w, "port%d=%d\n", n+1, s.Port)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// Write services as a JSON array, one record per line, without
+// building the whole document in memory
+func writeJSON(w io.Writer, discovered []Service) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("[")
+	for i, s := range discovered {
+		record, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n  ")
+		bw.Write(record)
+	}
+	if len(discovered) > 0 {
+		bw.WriteString("\n")
+	}
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// Synthetic result of n services for the benchmarks
+func benchmarkServices(n int) []Service {
+	discovered := make([]Service, 0, n)
+	for i := 0; i < n; i++ {
+		discovered = append(discovered, Service{
+			ServiceType: "_http._tcp",
+			Instance:    fmt.Sprintf("web-%d", i),
+			Hostname:    fmt.Sprintf("host%d.local.", i),
+			Address:     fmt.Sprintf("10.%d.%d.%d", i>>16&0xff, i>>8&0xff, i&0xff),
+			Port:        80,
+			Text:        []string{"path=/", "version=1"},
+			TxtMap:      map[string]string{"path": "/", "version": "1"},
+		})
+	}
+	return discovered
+}
+
+func BenchmarkWriteJSON(b *testing.B) {
+	discovered := benchmarkServices(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := writeJSON(io.Discard, discovered)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The json output before records were streamed
+func BenchmarkMarshalIndentJSON(b *testing.B) {
+	discovered := benchmarkServices(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := json.MarshalIndent(discovered, "", "  ")
+		if err != nil {
+			b.Fatal(err)
+		}
+		io.Discard.Write(data)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonLDNode struct {
+	ID       string   `json:"@id"`
+	Type     string   `json:"@type"`
+	Service  string   `json:"service"`
+	Instance string   `json:"instance"`
+	Hostname string   `json:"hostname"`
+	Address  string   `json:"address"`
+	Port     int      `json:"port"`
+	Txt      []string `json:"txt"`
+}
+
+var jsonLDContext = map[string]string{
+	"@vocab":   "http://schema.org/",
+	"service":  "http://schema.org/Service",
+	"hostname": "http://schema.org/name",
+	"address":  "http://schema.org/ipAddressFamily",
+}
+
+// Write services as JSON-LD nodes of type Service in a @graph
+func writeJSONLD(w io.Writer, discovered []Service) error {
+	graph := make([]jsonLDNode, 0, len(discovered))
+	for _, s := range discovered {
+		graph = append(graph, jsonLDNode{
+			ID:       "urn:mdns:" + buildKey(s),
+			Type:     "Service",
+			Service:  s.ServiceType,
+			Instance: s.Instance,
+			Hostname: s.Hostname,
+			Address:  s.Address,
+			Port:     s.Port,
+			Txt:      s.Text,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{
+		"@context": jsonLDContext,
+		"@graph":   graph,
+	})
+}
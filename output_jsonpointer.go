@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Walk the next JSON value from dec and write a line per scalar,
+// empty array and empty object with its pointer and JSON value
+func writeJSONPointerValue(w io.Writer, dec *json.Decoder, pointer string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		value, err := json.Marshal(tok)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\t%s\n", pointer, value)
+		return err
+	}
+
+	empty := true
+	for i := 0; dec.More(); i++ {
+		empty = false
+		child := pointer + "/" + strconv.Itoa(i)
+		if '{' == delim {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			child = pointer + "/" + jsonPointerEscaper.Replace(key.(string))
+		}
+		if err := writeJSONPointerValue(w, dec, child); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	if !empty {
+		return nil
+	}
+	value := "[]"
+	if '{' == delim {
+		value = "{}"
+	}
+	_, err = fmt.Fprintf(w, "%s\t%s\n", pointer, value)
+	return err
+}
+
+// Write RFC 6901 JSON Pointers into the json output with their values
+func writeJSONPointer(w io.Writer, discovered []Service) error {
+	doc, err := json.Marshal(discovered)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	return writeJSONPointerValue(w, dec, "")
+}
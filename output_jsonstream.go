@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Write services as JSON lines, batching size records into an array
+// per line, a size of one writes one record per line
+type jsonStream struct {
+	w       io.Writer
+	size    int
+	pending []Service
+}
+
+func newJSONStream(w io.Writer, size int) *jsonStream {
+	if size < 1 {
+		size = 1
+	}
+	return &jsonStream{w: w, size: size}
+}
+
+// Queue services and write every complete chunk
+func (s *jsonStream) add(services []Service) error {
+	s.pending = append(s.pending, services...)
+	for len(s.pending) >= s.size {
+		if err := s.emit(s.pending[:s.size]); err != nil {
+			return err
+		}
+		s.pending = s.pending[s.size:]
+	}
+	return nil
+}
+
+// Write the remaining records as a final, shorter chunk
+func (s *jsonStream) flush() error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	err := s.emit(s.pending)
+	s.pending = nil
+	return err
+}
+
+func (s *jsonStream) emit(chunk []Service) error {
+	var line []byte
+	var err error
+	if s.size == 1 {
+		line, err = json.Marshal(chunk[0])
+	} else {
+		line, err = json.Marshal(chunk)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "%s\n", line)
+	return err
+}
+
+func writeJSONStream(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	s := newJSONStream(w, cfg.JSONChunkSize)
+	if err := s.add(discovered); err != nil {
+		return err
+	}
+	return s.flush()
+}
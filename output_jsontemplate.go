@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+var jsonTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		out, err := json.Marshal(v)
+		return string(out), err
+	},
+}
+
+// Parse a --json-template, the template receives []Service
+func parseJSONTemplate(text string) (*template.Template, error) {
+	return template.New("json-template").Funcs(jsonTemplateFuncs).Parse(text)
+}
+
+func writeJSONTemplate(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	if cfg.JSONTemplate == nil {
+		return fmt.Errorf("output mode json-template requires --json-template")
+	}
+	return cfg.JSONTemplate.Execute(w, discovered)
+}
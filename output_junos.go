@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+// Write set commands adding a static host mapping per hostname and address
+func writeJunos(w io.Writer, discovered []Service) error {
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil {
+			continue
+		}
+		family := "inet"
+		if ip.To4() == nil {
+			family = "inet6"
+		}
+		line := fmt.Sprintf("set system static-host-mapping %s %s %s", shortHostname(s.Hostname), family, s.Address)
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
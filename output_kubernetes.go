@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type k8sEndpointSlice struct {
+	APIVersion  string        `yaml:"apiVersion"`
+	Kind        string        `yaml:"kind"`
+	Metadata    k8sMetadata   `yaml:"metadata"`
+	AddressType string        `yaml:"addressType"`
+	Endpoints   []k8sEndpoint `yaml:"endpoints"`
+	Ports       []k8sPort     `yaml:"ports"`
+}
+
+type k8sMetadata struct {
+	Name   string            `yaml:"name"`
+	Labels map[string]string `yaml:"labels"`
+}
+
+type k8sEndpoint struct {
+	Addresses []string `yaml:"addresses"`
+	Hostname  string   `yaml:"hostname,omitempty"`
+}
+
+type k8sPort struct {
+	Name     string `yaml:"name"`
+	Protocol string `yaml:"protocol"`
+	Port     int    `yaml:"port"`
+}
+
+// Build one EndpointSlice per service type, address family and port
+// since all endpoints of a slice share the address type and ports
+func k8sEndpointSlices(discovered []Service) []k8sEndpointSlice {
+	var slices []k8sEndpointSlice
+	index := make(map[string]int)
+
+	types, groups := groupByServiceType(discovered)
+	for _, serviceType := range types {
+		for _, s := range groups[serviceType] {
+			addressType := "IPv4"
+			if ip := net.ParseIP(s.Address); ip != nil && ip.To4() == nil {
+				addressType = "IPv6"
+			}
+
+			name := strings.ToLower(fmt.Sprintf("mdns-%s-%s-%d", sanitizeName(serviceType), addressType, s.Port))
+			i, ok := index[name]
+			if !ok {
+				protocol := "TCP"
+				if strings.HasSuffix(serviceType, "._udp") {
+					protocol = "UDP"
+				}
+				i = len(slices)
+				index[name] = i
+				slices = append(slices, k8sEndpointSlice{
+					APIVersion: "discovery.k8s.io/v1",
+					Kind:       "EndpointSlice",
+					Metadata: k8sMetadata{
+						Name: name,
+						Labels: map[string]string{
+							"endpointslice.kubernetes.io/managed-by": "mdns-discover",
+							"mdns-discover/service-type":             sanitizeName(serviceType),
+						},
+					},
+					AddressType: addressType,
+					Ports: []k8sPort{{
+						Name:     strings.ToLower(sanitizeName(strings.SplitN(serviceType, ".", 2)[0])),
+						Protocol: protocol,
+						Port:     s.Port,
+					}},
+				})
+			}
+
+			hostname := strings.ToLower(sanitizeName(shortHostname(s.Hostname)))
+			if len(hostname) > 63 {
+				hostname = strings.TrimRight(hostname[:63], "-")
+			}
+			slices[i].Endpoints = append(slices[i].Endpoints, k8sEndpoint{
+				Addresses: []string{s.Address},
+				Hostname:  hostname,
+			})
+		}
+	}
+	return slices
+}
+
+// Write the EndpointSlices as YAML documents separated by ---
+func writeKubernetes(w io.Writer, discovered []Service) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	for _, slice := range k8sEndpointSlices(discovered) {
+		err := enc.Encode(slice)
+		if err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// Build a Loki push request with one stream per service type
+func lokiPayload(discovered []Service) ([]byte, error) {
+	ts := strconv.FormatInt(time.Now().UnixNano(), 10)
+	types, groups := groupByServiceType(discovered)
+
+	push := lokiPush{Streams: make([]lokiStream, 0, len(types))}
+	for _, serviceType := range types {
+		stream := lokiStream{
+			Stream: map[string]string{"job": "mdns-discover", "service": serviceType},
+		}
+		for _, s := range groups[serviceType] {
+			line := fmt.Sprintf("instance=%s hostname=%s address=%s port=%d txt=%s",
+				strconv.Quote(s.Instance), s.Hostname, s.Address, s.Port, strconv.Quote(strings.Join(s.Text, ",")))
+			stream.Values = append(stream.Values, [2]string{ts, line})
+		}
+		push.Streams = append(push.Streams, stream)
+	}
+	return json.Marshal(push)
+}
+
+// Push the services to <url>/loki/api/v1/push, or print the payload without a URL
+func writeLoki(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	payload, err := lokiPayload(discovered)
+	if err != nil {
+		return err
+	}
+	if "" == cfg.LokiURL {
+		_, err = fmt.Fprintf(w, "%s\n", payload)
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(cfg.LokiURL, "/") + "/loki/api/v1/push"
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("loki returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"time"
+)
+
+// Expiry of the cached services in seconds
+const memcachedTTL = 300
+
+// Longest key memcached accepts
+const memcachedMaxKeyLength = 250
+
+// Build a text protocol set command per service at mdns/<key> with the
+// service as JSON, keys memcached would reject are left out
+func memcachedCommands(discovered []Service) ([][]byte, error) {
+	var commands [][]byte
+	for _, s := range discovered {
+		key := "mdns/" + buildKey(s)
+		if len(key) > memcachedMaxKeyLength || strings.ContainsAny(key, " \t\r\n") {
+			log.Println("Warning: Skipping service with invalid memcached key", key)
+			continue
+		}
+		value, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "set %s 0 %d %d\r\n", key, memcachedTTL, len(value))
+		b.Write(value)
+		b.WriteString("\r\n")
+		commands = append(commands, b.Bytes())
+	}
+	return commands, nil
+}
+
+func writeMemcached(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	commands, err := memcachedCommands(discovered)
+	if err != nil {
+		return err
+	}
+
+	if "" != cfg.MemcachedAddr {
+		return setMemcached(cfg.MemcachedAddr, commands)
+	}
+
+	for _, command := range commands {
+		_, err := w.Write(command)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send the set commands over a single connection, expecting STORED for each
+func setMemcached(addr string, commands [][]byte) error {
+	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	r := bufio.NewReader(conn)
+	for _, command := range commands {
+		_, err := conn.Write(command)
+		if err != nil {
+			return err
+		}
+		reply, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if reply = strings.TrimSpace(reply); "STORED" != reply {
+			return fmt.Errorf("memcached returned %s", reply)
+		}
+	}
+	return nil
+}
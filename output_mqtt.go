@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const mqttTimeout = 10 * time.Second
+
+type mqttMessage struct {
+	Topic   string
+	Payload []byte
+	Retain  bool
+}
+
+type mqttSummary struct {
+	Services     int            `json:"services"`
+	ServiceTypes map[string]int `json:"service_types"`
+	Timestamp    string         `json:"timestamp"`
+}
+
+func mqttServiceTopic(s Service) string {
+	return "mdns/" + s.ServiceType + "/" + strings.TrimSuffix(s.Hostname, ".")
+}
+
+// Build a message per service at mdns/<service_type>/<hostname>
+// followed by a summary at mdns/summary
+func mqttMessages(discovered []Service) ([]mqttMessage, error) {
+	messages := make([]mqttMessage, 0, len(discovered)+1)
+	summary := mqttSummary{ServiceTypes: make(map[string]int), Timestamp: time.Now().Format(time.RFC3339)}
+	for _, s := range discovered {
+		payload, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, mqttMessage{
+			Topic:   mqttServiceTopic(s),
+			Payload: payload,
+		})
+		summary.Services++
+		summary.ServiceTypes[s.ServiceType]++
+	}
+
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return nil, err
+	}
+	return append(messages, mqttMessage{Topic: "mdns/summary", Payload: payload}), nil
+}
+
+func writeMQTT(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	messages, err := mqttMessages(discovered)
+	if err != nil {
+		return err
+	}
+
+	if "" != cfg.MQTTBroker {
+		return publishMQTT(cfg.MQTTBroker, messages)
+	}
+
+	for _, m := range messages {
+		_, err := fmt.Fprintf(w, "%s\t%s\n", m.Topic, m.Payload)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish with QoS 0 in a clean session
+func publishMQTT(broker string, messages []mqttMessage) error {
+	opts := mqtt.NewClientOptions().
+		AddBroker(broker).
+		SetClientID(fmt.Sprintf("mdns-discover-%d", os.Getpid())).
+		SetCleanSession(true).
+		SetConnectTimeout(mqttTimeout)
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(mqttTimeout) {
+		return fmt.Errorf("timeout connecting to %s", broker)
+	}
+	if token.Error() != nil {
+		return token.Error()
+	}
+	defer client.Disconnect(250)
+
+	for _, m := range messages {
+		token := client.Publish(m.Topic, 0, m.Retain, m.Payload)
+		if !token.WaitTimeout(mqttTimeout) {
+			return fmt.Errorf("timeout publishing to %s", m.Topic)
+		}
+		if token.Error() != nil {
+			return token.Error()
+		}
+	}
+	return nil
+}
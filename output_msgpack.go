@@ -0,0 +1,15 @@
+package main
+
+import (
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Write services as a MessagePack array of maps keyed by the json field
+// names, so converting it to JSON yields the json output
+func writeMessagePack(w io.Writer, discovered []Service) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	return enc.Encode(discovered)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Semicolons start comments in Nagios object configuration
+var nagiosValueEscaper = strings.NewReplacer(";", `\;`, "\n", " ", "\r", " ")
+
+type nagiosHost struct {
+	name     string
+	alias    string
+	address  string
+	groups   []string
+	services []Service
+}
+
+// Write Nagios 4 object configuration: a hostgroup per service type,
+// a host per hostname and a service per port offered by the host
+func writeNagiosConfig(w io.Writer, discovered []Service) error {
+	var hosts []*nagiosHost
+	index := make(map[string]*nagiosHost)
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		name := shortHostname(s.Hostname)
+		h, ok := index[name]
+		if !ok {
+			h = &nagiosHost{name: name, alias: s.Instance}
+			index[name] = h
+			hosts = append(hosts, h)
+		}
+		// Prefer the first IPv4 address, fall back to the first IPv6 one
+		if ip := net.ParseIP(s.Address); ip != nil {
+			if "" == h.address || (ip.To4() != nil && net.ParseIP(h.address).To4() == nil) {
+				h.address = s.Address
+			}
+		}
+		group := "mdns-" + sanitizeName(s.ServiceType)
+		if key := name + "/" + group; !seen[key] {
+			seen[key] = true
+			h.groups = append(h.groups, group)
+		}
+		if key := fmt.Sprintf("%s/%s/%d", name, s.ServiceType, s.Port); !seen[key] {
+			seen[key] = true
+			h.services = append(h.services, s)
+		}
+	}
+
+	types, _ := groupByServiceType(discovered)
+	for _, t := range types {
+		fmt.Fprintln(w, "define hostgroup {")
+		fmt.Fprintf(w, "    hostgroup_name  mdns-%s\n", sanitizeName(t))
+		fmt.Fprintf(w, "    alias           mDNS %s\n", nagiosValueEscaper.Replace(t))
+		fmt.Fprintln(w, "}")
+		fmt.Fprintln(w)
+	}
+
+	for _, h := range hosts {
+		name := nagiosValueEscaper.Replace(h.name)
+		fmt.Fprintln(w, "define host {")
+		fmt.Fprintln(w, "    use             generic-host")
+		fmt.Fprintf(w, "    host_name       %s\n", name)
+		fmt.Fprintf(w, "    alias           %s\n", nagiosValueEscaper.Replace(h.alias))
+		fmt.Fprintf(w, "    address         %s\n", h.address)
+		fmt.Fprintln(w, "    check_command   check-host-alive")
+		fmt.Fprintf(w, "    hostgroups      %s\n", strings.Join(h.groups, ","))
+		fmt.Fprintln(w, "}")
+		fmt.Fprintln(w)
+
+		for _, s := range h.services {
+			command := "check_tcp"
+			if strings.HasSuffix(s.ServiceType, "._udp") {
+				command = "check_udp"
+			}
+			fmt.Fprintln(w, "define service {")
+			fmt.Fprintln(w, "    use                 generic-service")
+			fmt.Fprintf(w, "    host_name           %s\n", name)
+			fmt.Fprintf(w, "    service_description mdns-%s-%d\n", sanitizeName(s.ServiceType), s.Port)
+			fmt.Fprintf(w, "    check_command       %s!%d\n", command, s.Port)
+			fmt.Fprintln(w, "}")
+			_, err := fmt.Fprintln(w)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type netBoxIPAddress struct {
+	Address      string             `json:"address"`
+	Description  string             `json:"description"`
+	CustomFields netBoxCustomFields `json:"custom_fields"`
+}
+
+type netBoxCustomFields struct {
+	ServiceType string `json:"mdns_service_type"`
+	Port        int    `json:"mdns_port"`
+}
+
+// Build the payload for a bulk import via /api/ipam/ip-addresses/
+func netBoxPayload(discovered []Service) ([]byte, error) {
+	addresses := make([]netBoxIPAddress, 0, len(discovered))
+	for _, s := range discovered {
+		prefix := "/32"
+		if ip := net.ParseIP(s.Address); ip != nil && ip.To4() == nil {
+			prefix = "/128"
+		}
+		addresses = append(addresses, netBoxIPAddress{
+			Address:     s.Address + prefix,
+			Description: s.Hostname,
+			CustomFields: netBoxCustomFields{
+				ServiceType: s.ServiceType,
+				Port:        s.Port,
+			},
+		})
+	}
+	return json.MarshalIndent(addresses, "", "  ")
+}
+
+func writeNetBox(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	payload, err := netBoxPayload(discovered)
+	if err != nil {
+		return err
+	}
+
+	if "" == cfg.NetBoxURL {
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	return postNetBox(cfg.NetBoxURL, cfg.NetBoxToken, payload)
+}
+
+func postNetBox(url string, token string, payload []byte) error {
+	endpoint := strings.TrimSuffix(url, "/") + "/api/ipam/ip-addresses/"
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if "" != token {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("netbox returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// Write one upstream block per service type with a server line per address
+func writeNginx(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	types, groups := groupByServiceType(discovered)
+	for i, serviceType := range types {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "upstream %s {\n", sanitizeName(serviceType))
+		for _, s := range groups[serviceType] {
+			line := "    server " + net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+			if "" != cfg.NginxWeightKey {
+				weight, err := strconv.Atoi(s.TxtMap[cfg.NginxWeightKey])
+				if err == nil && weight > 0 {
+					line += " weight=" + strconv.Itoa(weight)
+				}
+			}
+			fmt.Fprintln(w, line+";")
+		}
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
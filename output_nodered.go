@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Derive a stable node ID so importing a flow again replaces its nodes
+func nodeRedID(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Build a flow on its own tab with an inject, http request and debug node
+// per HTTP service address, the URL path is taken from the path TXT record
+func nodeRedFlow(discovered []Service) []map[string]interface{} {
+	tab := nodeRedID("mdns-discover")
+	flow := []map[string]interface{}{{
+		"id":    tab,
+		"type":  "tab",
+		"label": "mdns-discover",
+	}}
+
+	row := 0
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if "_http._tcp" != s.ServiceType {
+			continue
+		}
+		hostport := net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+		if seen[hostport] {
+			continue
+		}
+		seen[hostport] = true
+
+		path := parseTxt(s.Text)["path"]
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		key := buildKey(s)
+		inject, request, debug := nodeRedID("inject/"+key), nodeRedID("request/"+key), nodeRedID("debug/"+key)
+		y := 40 + row*60
+		row++
+
+		flow = append(flow,
+			map[string]interface{}{
+				"id":          inject,
+				"type":        "inject",
+				"z":           tab,
+				"name":        s.Instance,
+				"props":       []map[string]string{{"p": "payload"}},
+				"repeat":      "",
+				"once":        false,
+				"topic":       "",
+				"payload":     "",
+				"payloadType": "date",
+				"x":           140,
+				"y":           y,
+				"wires":       [][]string{{request}},
+			},
+			map[string]interface{}{
+				"id":     request,
+				"type":   "http request",
+				"z":      tab,
+				"name":   strings.TrimSuffix(s.Hostname, "."),
+				"method": "GET",
+				"ret":    "txt",
+				"url":    "http://" + hostport + path,
+				"tls":    "",
+				"x":      380,
+				"y":      y,
+				"wires":  [][]string{{debug}},
+			},
+			map[string]interface{}{
+				"id":       debug,
+				"type":     "debug",
+				"z":        tab,
+				"name":     s.Instance,
+				"active":   true,
+				"complete": "payload",
+				"x":        600,
+				"y":        y,
+				"wires":    [][]string{},
+			},
+		)
+	}
+	return flow
+}
+
+// Write a flow for the Node-RED import dialog
+func writeNodeRed(w io.Writer, discovered []Service) error {
+	out, err := json.MarshalIndent(nodeRedFlow(discovered), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
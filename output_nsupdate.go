@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// TTL of the records added by nsupdate
+const nsupdateTTL = 60
+
+// Build an nsupdate batch adding A and AAAA records of all hostnames
+// within a single update
+func nsupdateCommands(discovered []Service, cfg OutputConfig) []byte {
+	origin := strings.TrimSuffix(cfg.ZoneOrigin, ".") + "."
+	if "." == origin {
+		origin = "local."
+	}
+
+	var b bytes.Buffer
+	if "" != cfg.NSUpdateServer {
+		fmt.Fprintf(&b, "server %s\n", cfg.NSUpdateServer)
+	}
+	fmt.Fprintf(&b, "zone %s\n", origin)
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil {
+			continue
+		}
+		rrtype := "A"
+		if ip.To4() == nil {
+			rrtype = "AAAA"
+		}
+		record := fmt.Sprintf("%s. %d IN %s %s", strings.TrimSuffix(s.Hostname, "."), nsupdateTTL, rrtype, s.Address)
+		if !seen[record] {
+			seen[record] = true
+			fmt.Fprintf(&b, "update add %s\n", record)
+		}
+	}
+	fmt.Fprintln(&b, "send")
+	return b.Bytes()
+}
+
+// Write a named.conf key statement for a TSIG key given as
+// [algorithm:]name:secret, the algorithm defaults to hmac-sha256
+func nsupdateKeyFile(key string) (string, error) {
+	parts := strings.Split(key, ":")
+	algorithm := "hmac-sha256"
+	switch len(parts) {
+	case 2:
+	case 3:
+		algorithm, parts = parts[0], parts[1:]
+	default:
+		return "", fmt.Errorf("invalid TSIG key, expected [algorithm:]name:secret")
+	}
+
+	f, err := os.CreateTemp("", "mdns-discover-tsig-*.key")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "key \"%s\" {\n\talgorithm %s;\n\tsecret \"%s\";\n};\n", parts[0], algorithm, parts[1])
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// Print nsupdate commands, or run nsupdate with them when a server is given
+func writeNSUpdate(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	commands := nsupdateCommands(discovered, cfg)
+	if "" == cfg.NSUpdateServer {
+		_, err := w.Write(commands)
+		return err
+	}
+
+	var args []string
+	if "" != cfg.NSUpdateKey {
+		// Pass the key in a file so the secret does not show up in the process list
+		keyFile, err := nsupdateKeyFile(cfg.NSUpdateKey)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(keyFile)
+		args = append(args, "-k", keyFile)
+	}
+
+	cmd := exec.Command("nsupdate", args...)
+	cmd.Stdin = bytes.NewReader(commands)
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var openhabStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func openhabString(s string) string {
+	return `"` + openhabStringEscaper.Replace(s) + `"`
+}
+
+// Write a network:pingdevice Thing per hostname followed by HTTP and MQTT
+// binding Things for HTTP servers and MQTT brokers, one per instance
+// with a <service>-<instance>-<hostname> id
+func writeOpenHAB(w io.Writer, discovered []Service) error {
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		hostname := strings.TrimSuffix(s.Hostname, ".")
+		id := sanitizeName(shortHostname(s.Hostname))
+		if "" == id || seen[id] {
+			continue
+		}
+		seen[id] = true
+		fmt.Fprintf(w, "Thing network:pingdevice:%s %s [ hostname=%s, retry=1, timeout=5000, refreshInterval=60000 ]\n",
+			id, openhabString(shortHostname(s.Hostname)), openhabString(hostname))
+	}
+
+	for _, s := range discovered {
+		if "" == sanitizeName(s.Instance) {
+			continue
+		}
+		service := strings.TrimPrefix(strings.TrimSuffix(s.ServiceType, "._tcp"), "_")
+		id := sanitizeName(service) + "-" + sanitizeName(s.Instance) + "-" + sanitizeName(shortHostname(s.Hostname))
+		hostport := net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+		var uid, thing string
+		switch s.ServiceType {
+		case "_http._tcp", "_https._tcp":
+			uid = "http:url:" + id
+			thing = fmt.Sprintf("Thing %s %s [ baseURL=%s, refresh=60 ]",
+				uid, openhabString(s.Instance), openhabString(service+"://"+hostport+"/"))
+		case "_mqtt._tcp", "_secure-mqtt._tcp":
+			uid = "mqtt:broker:" + id
+			thing = fmt.Sprintf("Bridge %s %s [ host=%s, port=%d, secure=%t ]",
+				uid, openhabString(s.Instance), openhabString(s.Address), s.Port, "_secure-mqtt._tcp" == s.ServiceType)
+		default:
+			continue
+		}
+		if seen[uid] {
+			continue
+		}
+		seen[uid] = true
+		_, err := fmt.Fprintln(w, thing)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
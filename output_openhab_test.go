@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteOpenHABUniqueUIDs(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+		{ServiceType: "_https._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 443},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host2.local.", Address: "192.168.1.11", Port: 80},
+		{ServiceType: "_mqtt._tcp", Instance: "broker", Hostname: "host2.local.", Address: "192.168.1.11", Port: 1883},
+	}
+
+	var buf bytes.Buffer
+	err := writeOpenHAB(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Thing network:pingdevice:host1 "host1" [ hostname="host1.local", retry=1, timeout=5000, refreshInterval=60000 ]
+Thing network:pingdevice:host2 "host2" [ hostname="host2.local", retry=1, timeout=5000, refreshInterval=60000 ]
+Thing http:url:http-web-host1 "web" [ baseURL="http://192.168.1.10:80/", refresh=60 ]
+Thing http:url:https-web-host1 "web" [ baseURL="https://192.168.1.10:443/", refresh=60 ]
+Thing http:url:http-web-host2 "web" [ baseURL="http://192.168.1.11:80/", refresh=60 ]
+Bridge mqtt:broker:mqtt-broker-host2 "broker" [ host="192.168.1.11", port=1883, secure=false ]
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Replace characters OpenTSDB does not allow in tag values
+func opentsdbTag(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_./", r) {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// Write one OpenTSDB telnet put command per address
+func writeOpenTSDB(w io.Writer, discovered []Service) error {
+	timestamp := time.Now().Unix()
+	for _, s := range discovered {
+		_, err := fmt.Fprintf(w, "put mdns.service.up %d 1 service_type=%s hostname=%s address=%s port=%d\n",
+			timestamp,
+			opentsdbTag(s.ServiceType),
+			opentsdbTag(strings.TrimSuffix(s.Hostname, ".")),
+			opentsdbTag(s.Address),
+			s.Port)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Connect to the OpenTSDB telnet API, falling back to the given
+// writer with a warning if the connection fails
+func dialOpenTSDB(addr string, fallback io.Writer) (io.Writer, func()) {
+	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+	if err != nil {
+		log.Println("Warning: failed to connect to OpenTSDB, writing to stdout:", err.Error())
+		return fallback, func() {}
+	}
+	return conn, func() { conn.Close() }
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Service type of OpenVPN servers
+const openvpnServiceType = "_openvpn._udp"
+
+// TXT record keys with Base64 encoded PEM data embedded as inline files
+var openvpnInlineKeys = []string{"ca", "cert", "key"}
+
+// Write a client configuration with a remote line per discovered server
+// address, inline ca, cert and key blocks are taken from the first server
+// announcing them
+func writeOpenVPN(w io.Writer, discovered []Service) error {
+	var remotes []string
+	inline := make(map[string]string)
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if openvpnServiceType != s.ServiceType {
+			continue
+		}
+		remote := fmt.Sprintf("remote %s %d udp", s.Address, s.Port)
+		if !seen[remote] {
+			seen[remote] = true
+			remotes = append(remotes, remote)
+		}
+
+		txt := parseTxt(s.Text)
+		for _, key := range openvpnInlineKeys {
+			if _, ok := inline[key]; ok || "" == txt[key] {
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(txt[key])
+			if block, _ := pem.Decode(data); err != nil || block == nil {
+				fmt.Fprintf(w, "# %s (%s): %s TXT record is not Base64 encoded PEM\n", s.Instance, strings.TrimSuffix(s.Hostname, "."), key)
+				continue
+			}
+			inline[key] = strings.TrimSpace(string(data))
+		}
+	}
+	if len(remotes) == 0 {
+		_, err := fmt.Fprintln(w, "# No OpenVPN servers discovered")
+		return err
+	}
+
+	fmt.Fprintln(w, "client")
+	fmt.Fprintln(w, "dev tun")
+	fmt.Fprintln(w, "nobind")
+	for _, remote := range remotes {
+		fmt.Fprintln(w, remote)
+	}
+	for _, key := range openvpnInlineKeys {
+		if data, ok := inline[key]; ok {
+			fmt.Fprintf(w, "<%s>\n%s\n</%s>\n", key, data, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+)
+
+type opnsenseDnsmasq struct {
+	XMLName    xml.Name            `xml:"dnsmasq"`
+	StaticMaps []opnsenseStaticMap `xml:"staticmap"`
+}
+
+type opnsenseStaticMap struct {
+	Hostname string `xml:"hostname"`
+	IPAddr   string `xml:"ipaddr"`
+	Descr    string `xml:"descr"`
+}
+
+// Write a dnsmasq fragment with one static mapping per hostname,
+// the first discovered IPv4 address of a hostname is kept
+func writeOPNsense(w io.Writer, discovered []Service) error {
+	doc := opnsenseDnsmasq{}
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil || ip.To4() == nil {
+			continue
+		}
+		hostname := shortHostname(s.Hostname)
+		if seen[hostname] {
+			continue
+		}
+		seen[hostname] = true
+		doc.StaticMaps = append(doc.StaticMaps, opnsenseStaticMap{
+			Hostname: hostname,
+			IPAddr:   s.Address,
+			Descr:    "mdns-discover " + s.ServiceType,
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Maximum length of a PAN-OS object name
+const panosMaxNameLength = 63
+
+// Candidate config path of the address objects in the default vsys
+const panosAddressXPath = "/config/devices/entry[@name='localhost.localdomain']/vsys/entry[@name='vsys1']/address"
+
+type panosAddress struct {
+	XMLName xml.Name            `xml:"address"`
+	Entries []panosAddressEntry `xml:"entry"`
+}
+
+type panosAddressEntry struct {
+	XMLName     xml.Name `xml:"entry"`
+	Name        string   `xml:"name,attr"`
+	IPNetmask   string   `xml:"ip-netmask"`
+	Description string   `xml:"description"`
+}
+
+// Build one address object per hostname and IP version, named
+// mdns-<hostname> and mdns-<hostname>-v6
+func panosAddresses(discovered []Service) panosAddress {
+	doc := panosAddress{}
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil {
+			continue
+		}
+		name, netmask := "mdns-"+sanitizeName(shortHostname(s.Hostname)), s.Address+"/32"
+		if ip.To4() == nil {
+			name, netmask = name+"-v6", s.Address+"/128"
+		}
+		if len(name) > panosMaxNameLength {
+			name = name[:panosMaxNameLength]
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		doc.Entries = append(doc.Entries, panosAddressEntry{
+			Name:        name,
+			IPNetmask:   netmask,
+			Description: strings.TrimSuffix(s.Hostname, ".") + " " + s.ServiceType + " discovered by mdns-discover",
+		})
+	}
+	return doc
+}
+
+func writePANOS(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	doc := panosAddresses(discovered)
+	if "" != cfg.PANOSAPI {
+		return setPANOSAddresses(cfg.PANOSAPI, cfg.PANOSKey, doc)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
+
+// Merge the address objects into the candidate config with the XML API,
+// the change still needs to be committed on the firewall
+func setPANOSAddresses(api string, key string, doc panosAddress) error {
+	var element strings.Builder
+	for _, entry := range doc.Entries {
+		out, err := xml.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		element.Write(out)
+	}
+
+	form := url.Values{
+		"type":    {"config"},
+		"action":  {"set"},
+		"key":     {key},
+		"xpath":   {panosAddressXPath},
+		"element": {element.String()},
+	}
+	endpoint := strings.TrimSuffix(api, "/")
+	if !strings.HasSuffix(endpoint, "/api") {
+		endpoint += "/api"
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.PostForm(endpoint+"/", form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result struct {
+		Status string `xml:"status,attr"`
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 || xml.Unmarshal(body, &result) != nil || "success" != result.Status {
+		return fmt.Errorf("PAN-OS returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Row of the Parquet file with a column per Service field
+type parquetService struct {
+	ServiceType string            `parquet:"service_type"`
+	Instance    string            `parquet:"instance"`
+	Hostname    string            `parquet:"hostname"`
+	Address     string            `parquet:"address"`
+	Port        int32             `parquet:"port"`
+	Txt         []string          `parquet:"txt,list"`
+	TxtMap      map[string]string `parquet:"txt_map"`
+	TxtEncoding string            `parquet:"txt_encoding,optional"`
+	Cached      bool              `parquet:"cached"`
+}
+
+func newParquetService(s Service) parquetService {
+	return parquetService{
+		ServiceType: s.ServiceType,
+		Instance:    s.Instance,
+		Hostname:    s.Hostname,
+		Address:     s.Address,
+		Port:        int32(s.Port),
+		Txt:         s.Text,
+		TxtMap:      s.TxtMap,
+		TxtEncoding: s.TxtEncoding,
+		Cached:      s.Cached,
+	}
+}
+
+// Write services as a Parquet file with a single row group, parquet-go
+// records min/max statistics for every column chunk and page
+func writeParquet(w io.Writer, discovered []Service) error {
+	rows := make([]parquetService, 0, len(discovered))
+	for _, s := range discovered {
+		rows = append(rows, newParquetService(s))
+	}
+
+	writer := parquet.NewGenericWriter[parquetService](w, parquet.CreatedBy("mdns-discover", "", ""))
+	_, err := writer.Write(rows)
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
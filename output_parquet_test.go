@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestWriteParquetReadBack(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+			Text: []string{"path=/"}, TxtMap: map[string]string{"path": "/"}},
+		{ServiceType: "_ssh._tcp", Instance: "box", Hostname: "box.local.", Address: "192.168.1.11", Port: 22},
+	}
+
+	var buf bytes.Buffer
+	err := writeParquet(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := parquet.Read[parquetService](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(discovered) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(discovered))
+	}
+	for i, s := range discovered {
+		want := newParquetService(s)
+		if len(want.Txt) == 0 {
+			want.Txt = rows[i].Txt
+		}
+		if len(want.TxtMap) == 0 {
+			want.TxtMap = rows[i].TxtMap
+		}
+		if !reflect.DeepEqual(rows[i], want) {
+			t.Errorf("row %d: got %+v, want %+v", i, rows[i], want)
+		}
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := make(map[string]format.Statistics)
+	for _, c := range f.Metadata().RowGroups[0].Columns {
+		stats[c.MetaData.PathInSchema[0]] = c.MetaData.Statistics
+	}
+	for column, want := range map[string][2]string{
+		"service_type": {"_http._tcp", "_ssh._tcp"},
+		"hostname":     {"box.local.", "host1.local."},
+		"address":      {"192.168.1.10", "192.168.1.11"},
+		"port":         {"\x16\x00\x00\x00", "\x50\x00\x00\x00"},
+	} {
+		s := stats[column]
+		if string(s.MinValue) != want[0] || string(s.MaxValue) != want[1] {
+			t.Errorf("%s statistics: got min %q max %q, want %q %q", column, s.MinValue, s.MaxValue, want[0], want[1])
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PowerShell also ends single-quoted strings at the typographic
+// single quotes U+2018 to U+201B, each is escaped by doubling it
+var powershellQuotes = strings.NewReplacer(
+	"'", "''",
+	"\u2018", "\u2018\u2018",
+	"\u2019", "\u2019\u2019",
+	"\u201a", "\u201a\u201a",
+	"\u201b", "\u201b\u201b",
+)
+
+// Quote s as a PowerShell single-quoted string
+func powershellString(s string) string {
+	return "'" + powershellQuotes.Replace(s) + "'"
+}
+
+// Write services as an array of PowerShell custom objects
+func writePowerShell(w io.Writer, discovered []Service) error {
+	fmt.Fprintln(w, "@(")
+	for _, s := range discovered {
+		fmt.Fprintf(w, "    [PSCustomObject]@{ServiceType=%s; Hostname=%s; Address=%s; Port=%d; Text=%s}\n",
+			powershellString(s.ServiceType),
+			powershellString(s.Hostname),
+			powershellString(s.Address),
+			s.Port,
+			powershellString(strings.Join(s.Text, ",")))
+	}
+	_, err := fmt.Fprintln(w, ")")
+	return err
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestPowershellString(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		want  string
+	}{
+		{"web", "'web'"},
+		{"it's", "'it''s'"},
+		{"a‘b", "'a‘‘b'"},
+		{"’; calc; ’", "'’’; calc; ’’'"},
+		{"‚‛", "'‚‚‛‛'"},
+	} {
+		got := powershellString(tc.value)
+		if got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
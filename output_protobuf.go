@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	mdnspb "github.com/bbusse/mdns-discover/proto"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Convert services to the DiscoveryResult message of proto/mdns.proto
+func discoveryResult(discovered []Service) *mdnspb.DiscoveryResult {
+	result := &mdnspb.DiscoveryResult{}
+	for _, s := range discovered {
+		result.Services = append(result.Services, &mdnspb.Service{
+			ServiceType: s.ServiceType,
+			Instance:    s.Instance,
+			Hostname:    s.Hostname,
+			Address:     s.Address,
+			Port:        int32(s.Port),
+			Txt:         s.Text,
+			TxtMap:      s.TxtMap,
+			TxtEncoding: s.TxtEncoding,
+			Cached:      s.Cached,
+		})
+	}
+	return result
+}
+
+// Write a DiscoveryResult prefixed with its varint encoded length
+func writeProtobuf(w io.Writer, discovered []Service) error {
+	_, err := protodelim.MarshalTo(w, discoveryResult(discovered))
+	return err
+}
+
+// Write a DiscoveryResult in the protobuf JSON mapping, using the
+// field names of the proto file
+func writeProtobufJSON(w io.Writer, discovered []Service) error {
+	out, err := protojson.MarshalOptions{Multiline: true, UseProtoNames: true}.Marshal(discoveryResult(discovered))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var puppetStringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func puppetString(s string) string {
+	return "'" + puppetStringEscaper.Replace(s) + "'"
+}
+
+// Write an mdns_service resource declaration per address, the defined
+// type is in contrib/puppet/mdns_service
+func writePuppet(w io.Writer, discovered []Service) error {
+	for i, s := range discovered {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "mdns_service { %s:\n", puppetString(buildKey(s)))
+		fmt.Fprintf(w, "  hostname     => %s,\n", puppetString(s.Hostname))
+		fmt.Fprintf(w, "  address      => %s,\n", puppetString(s.Address))
+		fmt.Fprintf(w, "  port         => %d,\n", s.Port)
+		fmt.Fprintf(w, "  service_type => %s,\n", puppetString(s.ServiceType))
+		_, err := fmt.Fprintln(w, "}")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Format services in the Prometheus text exposition format
+func prometheusText(discovered []Service) []byte {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "# HELP mdns_service_up Discovered mDNS service address")
+	fmt.Fprintln(&b, "# TYPE mdns_service_up gauge")
+	for _, s := range discovered {
+		fmt.Fprintf(&b, "mdns_service_up{service_type=\"%s\",hostname=\"%s\",address=\"%s\",port=\"%d\"} 1\n",
+			prometheusLabelEscaper.Replace(s.ServiceType),
+			prometheusLabelEscaper.Replace(s.Hostname),
+			prometheusLabelEscaper.Replace(s.Address),
+			s.Port)
+	}
+
+	types, groups := groupByServiceType(discovered)
+	fmt.Fprintln(&b, "# HELP mdns_services_discovered Number of discovered addresses per service type")
+	fmt.Fprintln(&b, "# TYPE mdns_services_discovered gauge")
+	for _, serviceType := range types {
+		fmt.Fprintf(&b, "mdns_services_discovered{service_type=\"%s\"} %d\n",
+			prometheusLabelEscaper.Replace(serviceType), len(groups[serviceType]))
+	}
+	return b.Bytes()
+}
+
+// Push the metrics to <url>/metrics/job/<job>/instance/<hostname>
+func writePushgateway(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	metrics := prometheusText(discovered)
+	if "" == cfg.PushgatewayURL {
+		_, err := w.Write(metrics)
+		return err
+	}
+
+	job := cfg.PushgatewayJob
+	if "" == job {
+		job = "mdns-discover"
+	}
+	instance, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(cfg.PushgatewayURL, "/") + "/metrics/job/" +
+		url.PathEscape(job) + "/instance/" + url.PathEscape(instance)
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(metrics))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("pushgateway returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
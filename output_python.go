@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Quote s as a Python string literal, Go escapes are valid in Python
+func pythonString(s string) string {
+	return strconv.Quote(s)
+}
+
+// Format a string slice as a Python list, None if unset
+func pythonList(values []string) string {
+	if values == nil {
+		return "None"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = pythonString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// Write services as a Python list of dicts literal for eval() or
+// ast.literal_eval(), with module a module defining it as services
+func writePython(w io.Writer, discovered []Service, module bool) error {
+	if module {
+		fmt.Fprintln(w, "# Generated by mdns-discover")
+		fmt.Fprint(w, "services = ")
+	}
+	fmt.Fprintln(w, "[")
+	for _, s := range discovered {
+		fmt.Fprintln(w, "    {")
+		fmt.Fprintf(w, "        \"service_type\": %s,\n", pythonString(s.ServiceType))
+		fmt.Fprintf(w, "        \"instance\": %s,\n", pythonString(s.Instance))
+		fmt.Fprintf(w, "        \"hostname\": %s,\n", pythonString(s.Hostname))
+		fmt.Fprintf(w, "        \"address\": %s,\n", pythonString(s.Address))
+		fmt.Fprintf(w, "        \"port\": %d,\n", s.Port)
+		fmt.Fprintf(w, "        \"txt\": %s,\n", pythonList(s.Text))
+		fmt.Fprintln(w, "    },")
+	}
+	_, err := fmt.Fprintln(w, "]")
+	return err
+}
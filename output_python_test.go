@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+var pythonTestServices = []Service{
+	{ServiceType: "_http._tcp", Instance: "web \"1\"", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80,
+		Text: []string{"path=/", "note=café"}},
+	{ServiceType: "_ssh._tcp", Instance: "box", Hostname: "box.local.", Address: "fe80::1", Port: 22},
+}
+
+// Summary of the services the Python snippets print
+const pythonTestSummary = "2 web \"1\" ['path=/', 'note=café'] None\n"
+
+func TestWritePythonEval(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not found")
+	}
+
+	var buf bytes.Buffer
+	err = writePython(&buf, pythonTestServices, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(python, "-c", "import sys; s = eval(sys.stdin.read()); print(len(s), s[0]['instance'], s[0]['txt'], s[1]['txt'])")
+	cmd.Stdin = &buf
+	out, err := cmd.CombinedOutput()
+	if err != nil || string(out) != pythonTestSummary {
+		t.Errorf("eval: got %q, %v", out, err)
+	}
+}
+
+func TestWritePythonModule(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not found")
+	}
+
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	err = writePython(&buf, pythonTestServices, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "mdns_results.py"), buf.Bytes(), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(python, "-c", "from mdns_results import services as s; print(len(s), s[0]['instance'], s[0]['txt'], s[1]['txt'])")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil || string(out) != pythonTestSummary {
+		t.Errorf("import: got %q, %v", out, err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Expiry of the cached service hashes
+const redisTTL = 300 * time.Second
+
+// Build an HSET per service at mdns:<key> and an EXPIRE, HSET has no
+// expiry option of its own
+func redisCommands(discovered []Service) [][]string {
+	commands := make([][]string, 0, 2*len(discovered))
+	for _, s := range discovered {
+		key := "mdns:" + buildKey(s)
+		commands = append(commands,
+			[]string{"HSET", key,
+				"hostname", strings.TrimSuffix(s.Hostname, "."),
+				"address", s.Address,
+				"port", strconv.Itoa(s.Port),
+				"service", s.ServiceType,
+				"txt", strings.Join(s.Text, " "),
+			},
+			[]string{"EXPIRE", key, strconv.Itoa(int(redisTTL.Seconds()))},
+		)
+	}
+	return commands
+}
+
+// Quote an argument for redis-cli when it is empty or contains
+// whitespace, quotes or non-printable characters
+func redisQuote(arg string) string {
+	plain := "" != arg
+	for i := 0; i < len(arg); i++ {
+		if arg[i] <= ' ' || arg[i] > '~' || '"' == arg[i] || '\'' == arg[i] || '\\' == arg[i] {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return arg
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(arg); i++ {
+		c := arg[i]
+		switch {
+		case '"' == c || '\\' == c:
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c > '~':
+			fmt.Fprintf(&b, "\\x%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func writeRedis(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	commands := redisCommands(discovered)
+
+	if "" != cfg.RedisAddr {
+		return execRedis(cfg.RedisAddr, cfg.RedisPassword, commands)
+	}
+
+	for _, command := range commands {
+		args := make([]string, len(command))
+		for i, arg := range command {
+			args[i] = redisQuote(arg)
+		}
+		_, err := fmt.Fprintln(w, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send all commands in a single pipeline
+func execRedis(addr string, password string, commands [][]string) error {
+	client := redis.NewClient(&redis.Options{Addr: addr, Password: password})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipe := client.Pipeline()
+	for _, command := range commands {
+		args := make([]interface{}, len(command))
+		for i, arg := range command {
+			args[i] = arg
+		}
+		pipe.Do(ctx, args...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
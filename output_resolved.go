@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Service types of domain controllers, the domain is taken from their
+// domain TXT record
+var domainControllerServiceTypes = map[string]bool{
+	"_ldap._tcp":     true,
+	"_kerberos._tcp": true,
+	"_kerberos._udp": true,
+}
+
+// Write a resolved.conf.d drop-in using the discovered DNS resolvers as
+// DNS= servers and the domains of discovered domain controllers as Domains=
+func writeSystemdResolved(w io.Writer, discovered []Service) error {
+	var servers, domains []string
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if dnsServiceTypes[s.ServiceType] && net.ParseIP(s.Address) != nil {
+			server := s.Address
+			if s.Port != 53 {
+				server = net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+			}
+			if !seen[server] {
+				seen[server] = true
+				servers = append(servers, server)
+			}
+		}
+		if domainControllerServiceTypes[s.ServiceType] {
+			domain := strings.Trim(parseTxt(s.Text)["domain"], ".")
+			if "" != domain && !seen["~"+domain] {
+				seen["~"+domain] = true
+				domains = append(domains, domain)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "# Generated by mdns-discover at %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintln(w, "[Resolve]")
+	if len(servers) == 0 {
+		fmt.Fprintln(w, "# No DNS resolvers discovered")
+	} else {
+		fmt.Fprintf(w, "DNS=%s\n", strings.Join(servers, " "))
+	}
+	if len(domains) == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintf(w, "Domains=%s\n", strings.Join(domains, " "))
+	return err
+}
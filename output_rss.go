@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	PubDate     string    `xml:"pubDate"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// Write an RSS 2.0 feed with one item per address
+func writeRSS(w io.Writer, discovered []Service) error {
+	now := time.Now().Format(time.RFC1123Z)
+	feed := rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "mDNS Discovery",
+			Link:        "https://github.com/bbusse/mdns-discover",
+			Description: "Services discovered by mdns-discover",
+			PubDate:     now,
+		},
+	}
+	for _, s := range discovered {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       strings.TrimSuffix(s.Hostname, ".") + " " + s.ServiceType,
+			Description: fmt.Sprintf("%s port %d %s", s.Address, s.Port, strings.Join(s.Text, " ")),
+			PubDate:     now,
+			GUID:        rssGUID{Value: buildKey(s)},
+		})
+	}
+
+	out, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, out)
+	return err
+}
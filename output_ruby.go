@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Quote s as a Ruby double-quoted string, escaping # to prevent interpolation
+func rubyString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\', '"', '#':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\x%02x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Write services as a Ruby array of hashes with symbol keys
+func writeRuby(w io.Writer, discovered []Service) error {
+	fmt.Fprintln(w, "[")
+	for _, s := range discovered {
+		fmt.Fprintf(w, "  {service_type: %s, hostname: %s, address: %s, port: %d, text: %s},\n",
+			rubyString(s.ServiceType),
+			rubyString(s.Hostname),
+			rubyString(s.Address),
+			s.Port,
+			rubyString(strings.Join(s.Text, ",")))
+	}
+	_, err := fmt.Fprintln(w, "]")
+	return err
+}
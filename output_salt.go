@@ -0,0 +1,21 @@
+package main
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Write a grains document with services grouped by type
+// under the mdns_discovered grain
+func writeSalt(w io.Writer, discovered []Service) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(map[string]interface{}{
+		"mdns_discovered": yamlServicesByType(discovered),
+	})
+	if err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// sFlow v5 structure formats, all with enterprise 0
+const (
+	sflowVersion       = 5
+	sflowFlowSample    = 1
+	sflowSampledIPv4   = 3
+	sflowSampledIPv6   = 4
+	sflowExtendedURL   = 1005
+	sflowAddressIPv4   = 1
+	sflowAddressIPv6   = 2
+	sflowURLSrc        = 1
+	sflowMaxStringSize = 255
+)
+
+// Start of the agent uptime reported in each datagram
+var sflowStart = time.Now()
+
+// Append XDR encoded values to a buffer
+type xdrBuffer struct {
+	bytes.Buffer
+}
+
+func (b *xdrBuffer) uint32(v uint32) {
+	binary.Write(&b.Buffer, binary.BigEndian, v)
+}
+
+func (b *xdrBuffer) opaque(data []byte) {
+	b.uint32(uint32(len(data)))
+	b.Write(data)
+	if pad := len(data) % 4; pad != 0 {
+		b.Write(make([]byte, 4-pad))
+	}
+}
+
+func (b *xdrBuffer) string(s string) {
+	if len(s) > sflowMaxStringSize {
+		s = s[:sflowMaxStringSize]
+	}
+	b.opaque([]byte(s))
+}
+
+// Append an IPv4 or IPv6 address preceded by its type
+func (b *xdrBuffer) address(ip net.IP) {
+	if ip4 := ip.To4(); ip4 != nil {
+		b.uint32(sflowAddressIPv4)
+		b.Write(ip4)
+		return
+	}
+	b.uint32(sflowAddressIPv6)
+	b.Write(ip.To16())
+}
+
+// Append a structure as format followed by its length and data
+func (b *xdrBuffer) record(format uint32, data []byte) {
+	b.uint32(format)
+	b.opaque(data)
+}
+
+// Encode a flow record with the sampled IP header of a service,
+// the service is the destination of the flow
+func sflowSampledIP(agent net.IP, s Service) (uint32, []byte) {
+	var protocol uint32 = 6
+	if strings.HasSuffix(s.ServiceType, "._udp") {
+		protocol = 17
+	}
+	ip := net.ParseIP(s.Address)
+	if ip == nil {
+		ip = net.IPv4zero
+	}
+
+	var b xdrBuffer
+	b.uint32(0) // length of the sampled packet is unknown
+	b.uint32(protocol)
+	if ip4 := ip.To4(); ip4 != nil {
+		src := agent.To4()
+		if src == nil {
+			src = net.IPv4zero.To4()
+		}
+		b.Write(src)
+		b.Write(ip4)
+		b.uint32(0)
+		b.uint32(uint32(s.Port))
+		b.uint32(0) // tcp_flags
+		b.uint32(0) // tos
+		return sflowSampledIPv4, b.Bytes()
+	}
+	src := net.IPv6zero
+	if agent.To4() == nil {
+		src = agent.To16()
+	}
+	b.Write(src)
+	b.Write(ip.To16())
+	b.uint32(0)
+	b.uint32(uint32(s.Port))
+	b.uint32(0) // tcp_flags
+	b.uint32(0) // priority
+	return sflowSampledIPv6, b.Bytes()
+}
+
+// Encode an sFlow v5 datagram with one flow sample describing a service
+func sflowDatagram(agent net.IP, sequence uint32, s Service) []byte {
+	var records xdrBuffer
+	format, data := sflowSampledIP(agent, s)
+	records.record(format, data)
+
+	var url xdrBuffer
+	url.uint32(sflowURLSrc)
+	url.string(fmt.Sprintf("%s://%s/%s", s.ServiceType, net.JoinHostPort(s.Address, fmt.Sprint(s.Port)), s.Instance))
+	url.string(strings.TrimSuffix(s.Hostname, "."))
+	records.record(sflowExtendedURL, url.Bytes())
+
+	var sample xdrBuffer
+	sample.uint32(sequence)
+	sample.uint32(0) // source_id, ifIndex 0
+	sample.uint32(1) // sampling_rate
+	sample.uint32(1) // sample_pool
+	sample.uint32(0) // drops
+	sample.uint32(0) // input
+	sample.uint32(0) // output
+	sample.uint32(2) // number of flow records
+	sample.Write(records.Bytes())
+
+	var b xdrBuffer
+	b.uint32(sflowVersion)
+	b.address(agent)
+	b.uint32(0) // sub_agent_id
+	b.uint32(sequence)
+	b.uint32(uint32(time.Since(sflowStart).Milliseconds()))
+	b.uint32(1) // number of samples
+	b.record(sflowFlowSample, sample.Bytes())
+	return b.Bytes()
+}
+
+// Send one sFlow v5 datagram per address to the collector, or print the
+// datagrams hex encoded without a collector
+func writeSFlow(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	agent := net.IPv4zero
+	var conn net.Conn
+	if "" != cfg.SFlowCollector {
+		var err error
+		conn, err = net.Dial("udp", cfg.SFlowCollector)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		agent = conn.LocalAddr().(*net.UDPAddr).IP
+	}
+
+	for i, s := range discovered {
+		datagram := sflowDatagram(agent, uint32(i+1), s)
+		var err error
+		if conn != nil {
+			_, err = conn.Write(datagram)
+		} else {
+			_, err = fmt.Fprintln(w, hex.EncodeToString(datagram))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+type shortcutsItem struct {
+	Hostname string `json:"hostname"`
+	Address  string `json:"address"`
+	Port     int    `json:"port"`
+	Service  string `json:"service"`
+}
+
+// Write services as a JSON dictionary for the Apple Shortcuts
+// "Get Dictionary from Input" action
+func writeShortcuts(w io.Writer, discovered []Service) error {
+	items := make([]shortcutsItem, 0, len(discovered))
+	for _, s := range discovered {
+		items = append(items, shortcutsItem{
+			Hostname: strings.TrimSuffix(s.Hostname, "."),
+			Address:  s.Address,
+			Port:     s.Port,
+			Service:  s.ServiceType,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string][]shortcutsItem{"items": items})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Number of service types listed in the summary
+const slackTopServiceTypes = 10
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type   string      `json:"type"`
+	Text   *slackText  `json:"text,omitempty"`
+	Fields []slackText `json:"fields,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Build a Block Kit message summarizing the discovered services,
+// services not in known are listed when known is not nil
+func slackPayload(discovered []Service, known map[string]bool) ([]byte, error) {
+	hosts := make(map[string]bool)
+	for _, s := range discovered {
+		hosts[s.Hostname] = true
+	}
+	summary := fmt.Sprintf("mDNS discovery: %d services on %d hosts", len(discovered), len(hosts))
+
+	msg := slackMessage{
+		Text: summary,
+		Blocks: []slackBlock{{
+			Type: "header",
+			Text: &slackText{Type: "plain_text", Text: summary},
+		}},
+	}
+
+	types, groups := groupByServiceType(discovered)
+	sort.SliceStable(types, func(i, j int) bool {
+		return len(groups[types[i]]) > len(groups[types[j]])
+	})
+	if len(types) > slackTopServiceTypes {
+		types = types[:slackTopServiceTypes]
+	}
+	if len(types) > 0 {
+		block := slackBlock{Type: "section"}
+		for _, serviceType := range types {
+			block.Fields = append(block.Fields, slackText{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("`%s`\n%d", serviceType, len(groups[serviceType])),
+			})
+		}
+		msg.Blocks = append(msg.Blocks, block)
+	}
+
+	if known != nil {
+		var lines []string
+		for _, s := range discovered {
+			if !known[buildKey(s)] {
+				lines = append(lines, fmt.Sprintf("• `%s` %s %s:%d", s.ServiceType, s.Hostname, s.Address, s.Port))
+			}
+		}
+		text := "*No new services*"
+		if len(lines) > 0 {
+			text = fmt.Sprintf("*%d new services*\n%s", len(lines), strings.Join(lines, "\n"))
+		}
+		msg.Blocks = append(msg.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: text},
+		})
+	}
+
+	return json.MarshalIndent(msg, "", "  ")
+}
+
+func writeSlack(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	var known map[string]bool
+	if "" != cfg.KnownServicesFile {
+		var err error
+		known, err = readKnownServices(cfg.KnownServicesFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	payload, err := slackPayload(discovered, known)
+	if err != nil {
+		return err
+	}
+
+	if "" != cfg.SlackWebhook {
+		err = postSlack(cfg.SlackWebhook, payload)
+		if err == nil {
+			return nil
+		}
+		log.Println("Warning: failed to post to Slack, writing payload to stdout:", err.Error())
+	}
+
+	_, err = fmt.Fprintln(w, string(payload))
+	return err
+}
+
+func postSlack(webhook string, payload []byte) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("slack returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Name of the Socket.IO event emitted per service
+const socketIOEvent = "mdns_service"
+
+type socketIOService struct {
+	Hostname    string `json:"hostname"`
+	Address     string `json:"address"`
+	Port        int    `json:"port"`
+	ServiceType string `json:"service_type"`
+}
+
+// Write one Socket.IO v4 EVENT packet per address, 4 is the
+// Engine.IO message type and 2 the Socket.IO event type
+func writeSocketIO(w io.Writer, discovered []Service) error {
+	for _, s := range discovered {
+		packet, err := json.Marshal([]interface{}{socketIOEvent, socketIOService{
+			Hostname:    s.Hostname,
+			Address:     s.Address,
+			Port:        s.Port,
+			ServiceType: s.ServiceType,
+		}})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "42%s\n", packet)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Write one SSDP NOTIFY message per address, each message
+// ends with an empty line
+func writeSSDP(w io.Writer, discovered []Service) error {
+	for _, s := range discovered {
+		hostname := strings.TrimSuffix(s.Hostname, ".")
+		_, err := fmt.Fprintf(w, "NOTIFY * HTTP/1.1\r\n"+
+			"HOST: 239.255.255.250:1900\r\n"+
+			"NT: %s\r\n"+
+			"NTS: ssdp:alive\r\n"+
+			"USN: %s::%s\r\n"+
+			"LOCATION: http://%s/\r\n\r\n",
+			s.ServiceType, hostname, s.ServiceType, net.JoinHostPort(s.Address, strconv.Itoa(s.Port)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
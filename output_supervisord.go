@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Write a [program:<hostname>_<service>] section per hostname and service
+// type, the command is a placeholder printing the first discovered address
+func writeSupervisord(w io.Writer, discovered []Service) error {
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		name := strings.ReplaceAll(sanitizeName(shortHostname(s.Hostname))+"_"+sanitizeName(s.ServiceType), "-", "_")
+		if seen[name] {
+			continue
+		}
+		if len(seen) > 0 {
+			fmt.Fprintln(w)
+		}
+		seen[name] = true
+		fmt.Fprintf(w, "[program:%s]\n", name)
+		fmt.Fprintf(w, "command=echo \"%s\"\n", net.JoinHostPort(s.Address, strconv.Itoa(s.Port)))
+		fmt.Fprintln(w, "autostart=true")
+		fmt.Fprintln(w, "autorestart=true")
+		_, err := fmt.Fprintf(w, "stdout_logfile=/var/log/supervisor/%s.log\n", name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
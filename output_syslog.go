@@ -0,0 +1,84 @@
+//go:build !windows && !plan9
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// Priority of the messages, facility daemon and severity info
+const syslogPriority = 3*8 + 6
+
+// Send one RFC 5424 message per service to the local syslog socket
+// or a remote syslog server
+func writeSyslog(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	conn, err := dialSyslog(cfg.SyslogAddr)
+	if err != nil {
+		log.Println("Warning: failed to connect to syslog, writing to stderr:", err.Error())
+		for _, s := range discovered {
+			fmt.Fprintln(os.Stderr, syslogMessage(s))
+		}
+		return nil
+	}
+	defer conn.Close()
+
+	hostname, err := os.Hostname()
+	if err != nil || "" == hostname {
+		hostname = "-"
+	}
+	for _, s := range discovered {
+		_, err = io.WriteString(conn, syslogFrame(s, hostname, os.Getpid(), time.Now()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Connect to a remote server over UDP or to the first local socket found,
+// like log/syslog does
+func dialSyslog(addr string) (net.Conn, error) {
+	if "" != addr {
+		return net.Dial("udp", addr)
+	}
+	var err error
+	for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+		var conn net.Conn
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err = net.Dial(network, path)
+			if err == nil {
+				return conn, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// Build an RFC 5424 message with header, structured data and message,
+// e.g. "<30>1 2026-01-02T15:04:05.000000Z host mdns-discover 42 - [mdns@0 ...] ..."
+func syslogFrame(s Service, hostname string, pid int, now time.Time) string {
+	return fmt.Sprintf("<%d>1 %s %s mdns-discover %d - %s",
+		syslogPriority,
+		now.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		hostname,
+		pid,
+		syslogMessage(s))
+}
+
+var syslogParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// Format a service as RFC 5424 structured data followed by the message
+func syslogMessage(s Service) string {
+	return fmt.Sprintf(`[mdns@0 service="%s" hostname="%s" addr="%s" port="%d"] %s discovered`,
+		syslogParamEscaper.Replace(s.ServiceType),
+		syslogParamEscaper.Replace(s.Hostname),
+		syslogParamEscaper.Replace(s.Address),
+		s.Port,
+		s.ServiceType)
+}
@@ -0,0 +1,12 @@
+//go:build windows || plan9
+
+package main
+
+import (
+	"errors"
+	"io"
+)
+
+func writeSyslog(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	return errors.New("syslog output is not supported on this platform")
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type envInstance struct {
+	Name      string
+	Service   Service
+	Addresses []string
+}
+
+// Name each service instance after its service type, several instances
+// of a type are numbered from 1, e.g. HTTP_TCP_1. Service is the first
+// address of the instance, Addresses holds all of them
+func envInstances(discovered []Service) []envInstance {
+	types, groups := groupByServiceType(discovered)
+	var instances []envInstance
+	for _, t := range types {
+		var names []string
+		byName := make(map[string]*envInstance)
+		seen := make(map[[2]string]bool)
+		for _, s := range groups[t] {
+			instance, ok := byName[s.Instance]
+			if !ok {
+				names = append(names, s.Instance)
+				instance = &envInstance{Service: s}
+				byName[s.Instance] = instance
+			}
+			if !seen[[2]string{s.Instance, s.Address}] {
+				seen[[2]string{s.Instance, s.Address}] = true
+				instance.Addresses = append(instance.Addresses, s.Address)
+			}
+		}
+		for i, name := range names {
+			instance := byName[name]
+			instance.Name = envName(t)
+			if len(names) > 1 {
+				instance.Name += "_" + strconv.Itoa(i+1)
+			}
+			instances = append(instances, *instance)
+		}
+	}
+	return instances
+}
+
+// Write a [Service] drop-in with <SERVICE_TYPE>_HOST and <SERVICE_TYPE>_PORT
+// environment variables per service instance, an instance with several
+// addresses also gets every address as <SERVICE_TYPE>_HOST_1, _HOST_2
+func writeSystemdUnit(w io.Writer, discovered []Service) error {
+	fmt.Fprintln(w, "[Service]")
+	for _, instance := range envInstances(discovered) {
+		fmt.Fprintf(w, "Environment=\"%s_HOST=%s\"\n", instance.Name, instance.Service.Address)
+		if len(instance.Addresses) > 1 {
+			for i, address := range instance.Addresses {
+				fmt.Fprintf(w, "Environment=\"%s_HOST_%d=%s\"\n", instance.Name, i+1, address)
+			}
+		}
+		_, err := fmt.Fprintf(w, "Environment=\"%s_PORT=%d\"\n", instance.Name, instance.Service.Port)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
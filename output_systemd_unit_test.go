@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Two instances of _http._tcp, the first with two addresses
+var envTestServices = []Service{
+	{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+	{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+	{ServiceType: "_http._tcp", Instance: "admin", Hostname: "host2.local.", Address: "192.168.1.11", Port: 8080},
+	{ServiceType: "_ssh._tcp", Instance: "box", Hostname: "box.local.", Address: "192.168.1.12", Port: 22},
+}
+
+func TestWriteSystemdUnitEveryAddress(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeSystemdUnit(&buf, envTestServices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[Service]
+Environment="HTTP_TCP_1_HOST=192.168.1.10"
+Environment="HTTP_TCP_1_HOST_1=192.168.1.10"
+Environment="HTTP_TCP_1_HOST_2=fe80::1"
+Environment="HTTP_TCP_1_PORT=80"
+Environment="HTTP_TCP_2_HOST=192.168.1.11"
+Environment="HTTP_TCP_2_PORT=8080"
+Environment="SSH_TCP_HOST=192.168.1.12"
+Environment="SSH_TCP_PORT=22"
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
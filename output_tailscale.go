@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Infer the /24 or /64 subnet of every discovered address, link-local and
+// loopback addresses are not routable and left out
+func tailscaleRoutes(discovered []Service) []string {
+	var routes []string
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		mask := net.CIDRMask(64, 128)
+		if ip.To4() != nil {
+			ip, mask = ip.To4(), net.CIDRMask(24, 32)
+		}
+		route := (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+		if !seen[route] {
+			seen[route] = true
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// Print a tailscale set command advertising the discovered subnets,
+// or run it with --tailscale-run
+func writeTailscale(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	routes := tailscaleRoutes(discovered)
+	if len(routes) == 0 {
+		_, err := fmt.Fprintln(w, "# No routable subnets discovered")
+		return err
+	}
+	args := []string{"set", "--advertise-routes=" + strings.Join(routes, ",")}
+
+	if cfg.TailscaleRun {
+		cmd := exec.Command("tailscale", args...)
+		cmd.Stdout = w
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+
+	fields := []string{"tailscale"}
+	for _, arg := range args {
+		fields = append(fields, shellQuote(arg))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(fields, " "))
+	return err
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Write a net_response input per TCP service address, HTTP services
+// get an http_response input as well, other services become comments
+func writeTelegraf(w io.Writer, discovered []Service) error {
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		address := net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+		key := s.ServiceType + " " + address
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if !strings.HasSuffix(s.ServiceType, "._tcp") {
+			fmt.Fprintf(w, "# %s %s %s has no TCP or HTTP check\n\n", s.ServiceType, strings.TrimSuffix(s.Hostname, "."), address)
+			continue
+		}
+
+		fmt.Fprintf(w, "# %s %s\n", s.ServiceType, strings.TrimSuffix(s.Hostname, "."))
+		fmt.Fprintln(w, "[[inputs.net_response]]")
+		fmt.Fprintln(w, "  protocol = \"tcp\"")
+		fmt.Fprintf(w, "  address = %s\n", strconv.Quote(address))
+		fmt.Fprintln(w, "  timeout = \"1s\"")
+		fmt.Fprintln(w)
+
+		scheme := ""
+		switch s.ServiceType {
+		case "_http._tcp":
+			scheme = "http"
+		case "_https._tcp":
+			scheme = "https"
+		}
+		if "" != scheme {
+			fmt.Fprintln(w, "[[inputs.http_response]]")
+			fmt.Fprintf(w, "  urls = [%s]\n", strconv.Quote(scheme+"://"+address+"/"))
+			fmt.Fprintln(w, "  response_timeout = \"1s\"")
+			_, err := fmt.Fprintln(w)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var hclStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "${", "$${", "%{", "%%{")
+
+func hclString(s string) string {
+	return `"` + hclStringEscaper.Replace(s) + `"`
+}
+
+// Write a locals block with a map of all services keyed by buildKey
+func writeTerraformHCL(w io.Writer, discovered []Service) error {
+	fmt.Fprintln(w, "locals {")
+	fmt.Fprintln(w, "  mdns_services = {")
+	for _, s := range discovered {
+		fmt.Fprintf(w, "    %s = {\n", hclString(buildKey(s)))
+		fmt.Fprintf(w, "      address      = %s\n", hclString(s.Address))
+		fmt.Fprintf(w, "      port         = %d\n", s.Port)
+		fmt.Fprintf(w, "      hostname     = %s\n", hclString(s.Hostname))
+		fmt.Fprintf(w, "      service_type = %s\n", hclString(s.ServiceType))
+		fmt.Fprintln(w, "    }")
+	}
+	fmt.Fprintln(w, "  }")
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestOutputIPVersion(t *testing.T) {
+	for _, tc := range []struct {
+		mode    OutputMode
+		version string
+		want    string
+	}{
+		{OutputText, "", "4"},
+		{OutputJSON, "", "4"},
+		{OutputText, "6", "6"},
+		{OutputText, "all", ""},
+		{OutputBINDZone, "", ""},
+		{OutputBINDZone, "4", "4"},
+		{OutputNSUpdate, "", ""},
+		{OutputUnbound, "", ""},
+		{OutputJunos, "", ""},
+		{OutputJunos, "4", "4"},
+		{OutputCiscoIOS, "", ""},
+		{OutputCoreDNSHosts, "", ""},
+		{OutputDockerExtraHosts, "", ""},
+	} {
+		got := outputIPVersion(tc.mode, tc.version)
+		if got != tc.want {
+			t.Errorf("%s with %q: got %q, want %q", tc.mode, tc.version, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type traefikConfig struct {
+	HTTP *traefikHTTP `yaml:"http,omitempty"`
+	TCP  *traefikTCP  `yaml:"tcp,omitempty"`
+	UDP  *traefikUDP  `yaml:"udp,omitempty"`
+}
+
+type traefikHTTP struct {
+	Routers  map[string]traefikRouter  `yaml:"routers"`
+	Services map[string]traefikService `yaml:"services"`
+}
+
+// Routers of _https._tcp services only take TLS requests,
+// so they don't conflict with _http._tcp routers of the same host
+type traefikRouter struct {
+	Rule    string            `yaml:"rule"`
+	Service string            `yaml:"service"`
+	TLS     *traefikRouterTLS `yaml:"tls,omitempty"`
+}
+
+type traefikRouterTLS struct{}
+
+type traefikService struct {
+	LoadBalancer traefikLoadBalancer `yaml:"loadBalancer"`
+}
+
+type traefikLoadBalancer struct {
+	Servers []traefikServer `yaml:"servers"`
+}
+
+type traefikServer struct {
+	URL string `yaml:"url"`
+}
+
+// TCP routers match any SNI, the service types aren't HTTP
+// and mostly not TLS either
+type traefikTCP struct {
+	Routers  map[string]traefikTCPRouter  `yaml:"routers"`
+	Services map[string]traefikTCPService `yaml:"services"`
+}
+
+type traefikTCPRouter struct {
+	Rule    string `yaml:"rule"`
+	Service string `yaml:"service"`
+}
+
+type traefikTCPService struct {
+	LoadBalancer traefikTCPLoadBalancer `yaml:"loadBalancer"`
+}
+
+type traefikTCPLoadBalancer struct {
+	Servers []traefikTCPServer `yaml:"servers"`
+}
+
+type traefikTCPServer struct {
+	Address string `yaml:"address"`
+}
+
+// UDP routers have no rule, they take the packets of all UDP entry points
+type traefikUDP struct {
+	Routers  map[string]traefikUDPRouter  `yaml:"routers"`
+	Services map[string]traefikUDPService `yaml:"services"`
+}
+
+type traefikUDPRouter struct {
+	Service string `yaml:"service"`
+}
+
+type traefikUDPService struct {
+	LoadBalancer traefikUDPLoadBalancer `yaml:"loadBalancer"`
+}
+
+type traefikUDPLoadBalancer struct {
+	Servers []traefikUDPServer `yaml:"servers"`
+}
+
+type traefikUDPServer struct {
+	Address string `yaml:"address"`
+}
+
+// Write a file provider configuration with a router and a load
+// balanced service per service instance. _http._tcp and _https._tcp
+// get HTTP routers on the hostname, other _tcp service types TCP
+// routers and _udp service types UDP routers
+func writeTraefik(w io.Writer, discovered []Service) error {
+	var cfg traefikConfig
+	for _, s := range discovered {
+		hostname := strings.TrimSuffix(s.Hostname, ".")
+		name := sanitizeName(s.ServiceType) + "-" + sanitizeName(shortHostname(s.Hostname))
+		address := net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+
+		switch {
+		case "_http._tcp" == s.ServiceType || "_https._tcp" == s.ServiceType:
+			if cfg.HTTP == nil {
+				cfg.HTTP = &traefikHTTP{
+					Routers:  make(map[string]traefikRouter),
+					Services: make(map[string]traefikService),
+				}
+			}
+			router := traefikRouter{Rule: "Host(`" + hostname + "`)", Service: name}
+			scheme := "http"
+			if "_https._tcp" == s.ServiceType {
+				router.TLS = &traefikRouterTLS{}
+				scheme = "https"
+			}
+			cfg.HTTP.Routers[name] = router
+			service := cfg.HTTP.Services[name]
+			service.LoadBalancer.Servers = append(service.LoadBalancer.Servers, traefikServer{
+				URL: scheme + "://" + address,
+			})
+			cfg.HTTP.Services[name] = service
+		case strings.HasSuffix(s.ServiceType, "._udp"):
+			if cfg.UDP == nil {
+				cfg.UDP = &traefikUDP{
+					Routers:  make(map[string]traefikUDPRouter),
+					Services: make(map[string]traefikUDPService),
+				}
+			}
+			cfg.UDP.Routers[name] = traefikUDPRouter{Service: name}
+			service := cfg.UDP.Services[name]
+			service.LoadBalancer.Servers = append(service.LoadBalancer.Servers, traefikUDPServer{Address: address})
+			cfg.UDP.Services[name] = service
+		default:
+			if cfg.TCP == nil {
+				cfg.TCP = &traefikTCP{
+					Routers:  make(map[string]traefikTCPRouter),
+					Services: make(map[string]traefikTCPService),
+				}
+			}
+			cfg.TCP.Routers[name] = traefikTCPRouter{Rule: "HostSNI(`*`)", Service: name}
+			service := cfg.TCP.Services[name]
+			service.LoadBalancer.Servers = append(service.LoadBalancer.Servers, traefikTCPServer{Address: address})
+			cfg.TCP.Services[name] = service
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return err
+	}
+	return enc.Close()
+}
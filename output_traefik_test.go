@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTraefik(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "web.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_https._tcp", Instance: "web", Hostname: "web.local.", Address: "192.168.1.10", Port: 443},
+		{ServiceType: "_http._tcp", Instance: "wiki", Hostname: "wiki.example.org.", Address: "192.168.1.11", Port: 8080},
+		{ServiceType: "_ssh._tcp", Instance: "web", Hostname: "web.local.", Address: "192.168.1.10", Port: 22},
+		{ServiceType: "_dns._udp", Instance: "resolver", Hostname: "ns.local.", Address: "192.168.1.53", Port: 53},
+		{ServiceType: "_dns._udp", Instance: "resolver", Hostname: "ns.local.", Address: "192.168.1.54", Port: 53},
+	}
+
+	var buf bytes.Buffer
+	err := writeTraefik(&buf, discovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `http:
+  routers:
+    http-tcp-web:
+      rule: Host(` + "`web.local`" + `)
+      service: http-tcp-web
+    http-tcp-wiki-example-org:
+      rule: Host(` + "`wiki.example.org`" + `)
+      service: http-tcp-wiki-example-org
+    https-tcp-web:
+      rule: Host(` + "`web.local`" + `)
+      service: https-tcp-web
+      tls: {}
+  services:
+    http-tcp-web:
+      loadBalancer:
+        servers:
+          - url: http://192.168.1.10:80
+    http-tcp-wiki-example-org:
+      loadBalancer:
+        servers:
+          - url: http://192.168.1.11:8080
+    https-tcp-web:
+      loadBalancer:
+        servers:
+          - url: https://192.168.1.10:443
+tcp:
+  routers:
+    ssh-tcp-web:
+      rule: HostSNI(` + "`*`" + `)
+      service: ssh-tcp-web
+  services:
+    ssh-tcp-web:
+      loadBalancer:
+        servers:
+          - address: 192.168.1.10:22
+udp:
+  routers:
+    dns-udp-ns:
+      service: dns-udp-ns
+  services:
+    dns-udp-ns:
+      loadBalancer:
+        servers:
+          - address: 192.168.1.53:53
+          - address: 192.168.1.54:53
+`
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
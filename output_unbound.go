@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Write an unbound.conf server clause with local-data and local-data-ptr
+// records per hostname and address, for use with include:
+func writeUnbound(w io.Writer, discovered []Service) error {
+	fmt.Fprintf(w, "# Generated by mdns-discover at %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintln(w, "server:")
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil {
+			continue
+		}
+		rrtype := "A"
+		if ip.To4() == nil {
+			rrtype = "AAAA"
+		}
+		name := strings.TrimRight(s.Hostname, ".")
+		entry := name + " " + s.Address
+		if seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		fmt.Fprintf(w, "    local-data: \"%s 60 IN %s %s\"\n", name, rrtype, s.Address)
+		_, err := fmt.Fprintf(w, "    local-data-ptr: \"%s %s\"\n", s.Address, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
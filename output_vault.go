@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type vaultSecret struct {
+	Path string
+	Data map[string]string
+}
+
+// Build one secret per service at mdns/<service_type>/<hostname>/<address>:<port>
+func vaultSecrets(discovered []Service) []vaultSecret {
+	var secrets []vaultSecret
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		path := "mdns/" + buildKey(s)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		secrets = append(secrets, vaultSecret{
+			Path: path,
+			Data: map[string]string{
+				"hostname":     s.Hostname,
+				"address":      s.Address,
+				"port":         strconv.Itoa(s.Port),
+				"service_type": s.ServiceType,
+				"txt":          strings.Join(s.Text, " "),
+			},
+		})
+	}
+	return secrets
+}
+
+func writeVault(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	mount := cfg.VaultMount
+	if "" == mount {
+		mount = "secret"
+	}
+	secrets := vaultSecrets(discovered)
+
+	if "" != cfg.VaultAddr {
+		client := &http.Client{Timeout: 10 * time.Second}
+		for _, secret := range secrets {
+			err := putVaultSecret(client, cfg.VaultAddr, cfg.VaultToken, mount, secret)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, secret := range secrets {
+		fields := []string{"vault", "kv", "put", shellQuote("-mount=" + mount), shellQuote(secret.Path)}
+		for _, key := range []string{"hostname", "address", "port", "service_type", "txt"} {
+			fields = append(fields, shellQuote(key+"="+secret.Data[key]))
+		}
+		_, err := fmt.Fprintln(w, strings.Join(fields, " "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write a secret with the KV v2 HTTP API
+func putVaultSecret(client *http.Client, addr string, token string, mount string, secret vaultSecret) error {
+	body, err := json.Marshal(map[string]interface{}{"data": secret.Data})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(addr, "/") + "/v1/" + strings.Trim(mount, "/") + "/data/" + secret.Path
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if "" != token {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("vault returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
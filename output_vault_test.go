@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestVaultSecretsPerService(t *testing.T) {
+	discovered := []Service{
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "admin", Hostname: "host1.local.", Address: "192.168.1.10", Port: 8080},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "fe80::1", Port: 80},
+		{ServiceType: "_http._tcp", Instance: "web", Hostname: "host1.local.", Address: "192.168.1.10", Port: 80},
+	}
+
+	var paths []string
+	for _, secret := range vaultSecrets(discovered) {
+		paths = append(paths, secret.Path)
+	}
+	want := []string{
+		"mdns/_http._tcp/host1.local/192.168.1.10:80",
+		"mdns/_http._tcp/host1.local/192.168.1.10:8080",
+		"mdns/_http._tcp/host1.local/[fe80::1]:80",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+	}))
+	defer server.Close()
+
+	err := writeVault(nil, discovered, OutputConfig{VaultAddr: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requested) != 3 || requested[2] != "/v1/secret/data/mdns/_http._tcp/host1.local/[fe80::1]:80" {
+		t.Errorf("got requests %v", requested)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+type scopeLatest struct {
+	Timestamp string `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+type scopeNode struct {
+	Latest map[string]scopeLatest `json:"latest"`
+}
+
+type scopeMetadataTemplate struct {
+	ID       string  `json:"id"`
+	Label    string  `json:"label"`
+	Priority float64 `json:"priority"`
+	From     string  `json:"from"`
+}
+
+type scopeTopology struct {
+	Nodes             map[string]scopeNode             `json:"nodes"`
+	MetadataTemplates map[string]scopeMetadataTemplate `json:"metadata_templates"`
+}
+
+type scopePlugin struct {
+	ID          string   `json:"id"`
+	Label       string   `json:"label"`
+	Description string   `json:"description"`
+	Interfaces  []string `json:"interfaces"`
+	APIVersion  string   `json:"api_version"`
+}
+
+type scopeReport struct {
+	Host    scopeTopology `json:"Host"`
+	Plugins []scopePlugin `json:"Plugins"`
+}
+
+// Latest entries reported per service, in display order
+var scopeFields = []struct{ id, label string }{
+	{"mdns_hostname", "mDNS hostname"},
+	{"mdns_address", "mDNS address"},
+	{"mdns_port", "mDNS port"},
+	{"mdns_service_type", "mDNS service type"},
+}
+
+// Write a Weave Scope probe plugin report with one node per service
+func writeWeaveScope(w io.Writer, discovered []Service) error {
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+	report := scopeReport{
+		Host: scopeTopology{
+			Nodes:             make(map[string]scopeNode),
+			MetadataTemplates: make(map[string]scopeMetadataTemplate),
+		},
+		Plugins: []scopePlugin{{
+			ID:          "mdns-discover",
+			Label:       "mDNS",
+			Description: "Services discovered via mDNS",
+			Interfaces:  []string{"reporter"},
+			APIVersion:  "1",
+		}},
+	}
+	for i, f := range scopeFields {
+		report.Host.MetadataTemplates[f.id] = scopeMetadataTemplate{
+			ID: f.id, Label: f.label, Priority: float64(i + 1), From: "latest",
+		}
+	}
+
+	for _, s := range discovered {
+		values := []string{s.Hostname, s.Address, strconv.Itoa(s.Port), s.ServiceType}
+		node := scopeNode{Latest: make(map[string]scopeLatest)}
+		for i, f := range scopeFields {
+			node.Latest[f.id] = scopeLatest{Timestamp: ts, Value: values[i]}
+		}
+		report.Host.Nodes[buildKey(s)] = node
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
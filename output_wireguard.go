@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Service type of WireGuard endpoints announcing their public key with pubkey=
+const wireguardServiceType = "_wireguard._udp"
+
+// Check for a base64 encoded 32 byte Curve25519 key
+func validWireGuardKey(key string) bool {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	return err == nil && len(raw) == 32
+}
+
+// Write a [Peer] block per WireGuard public key, peers announced on several
+// addresses use the first one as endpoint
+func writeWireGuard(w io.Writer, discovered []Service) error {
+	seen := make(map[string]bool)
+	invalid := make(map[string]bool)
+	count := 0
+	for _, s := range discovered {
+		if wireguardServiceType != s.ServiceType {
+			continue
+		}
+		name := strings.TrimSuffix(s.Hostname, ".")
+		key := strings.TrimSpace(parseTxt(s.Text)["pubkey"])
+		if !validWireGuardKey(key) {
+			if !invalid[s.Instance] {
+				invalid[s.Instance] = true
+				fmt.Fprintf(w, "# %s (%s): missing or invalid pubkey TXT record\n\n", s.Instance, name)
+			}
+			continue
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		count++
+
+		fmt.Fprintf(w, "# %s (%s)\n", s.Instance, name)
+		fmt.Fprintln(w, "[Peer]")
+		fmt.Fprintf(w, "PublicKey = %s\n", key)
+		fmt.Fprintf(w, "Endpoint = %s\n", net.JoinHostPort(s.Address, strconv.Itoa(s.Port)))
+		fmt.Fprintln(w, "AllowedIPs = 0.0.0.0/0")
+		fmt.Fprintln(w)
+	}
+	if count == 0 {
+		_, err := fmt.Fprintln(w, "# No WireGuard peers discovered")
+		return err
+	}
+	return nil
+}
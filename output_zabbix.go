@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type zabbixDiscovery struct {
+	Data []map[string]string `json:"data"`
+}
+
+// Write all services as Zabbix low-level discovery JSON
+func writeZabbix(w io.Writer, discovered []Service) error {
+	lld := zabbixDiscovery{Data: make([]map[string]string, 0, len(discovered))}
+	for _, s := range discovered {
+		lld.Data = append(lld.Data, map[string]string{
+			"{#HOST}":    s.Hostname,
+			"{#ADDRESS}": s.Address,
+			"{#PORT}":    strconv.Itoa(s.Port),
+			"{#SERVICE}": s.ServiceType,
+		})
+	}
+
+	out, err := json.Marshal(lld)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
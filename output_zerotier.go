@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Service type of ZeroTier nodes announcing their node ID with nodeid=
+const zerotierServiceType = "_zerotier._udp"
+
+const zerotierCentralURL = "https://my.zerotier.com/api/v1"
+
+type zerotierMember struct {
+	Config zerotierMemberConfig `json:"config"`
+	Name   string               `json:"name"`
+}
+
+type zerotierMemberConfig struct {
+	Authorized bool `json:"authorized"`
+}
+
+// Check for a ZeroTier address of 10 hex digits
+func validZeroTierNodeID(id string) bool {
+	if len(id) != 10 {
+		return false
+	}
+	for _, r := range id {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Build a member authorization per ZeroTier node ID, keyed by node ID
+func zerotierMembers(discovered []Service) ([]string, map[string]zerotierMember) {
+	var ids []string
+	members := make(map[string]zerotierMember)
+	for _, s := range discovered {
+		if zerotierServiceType != s.ServiceType {
+			continue
+		}
+		id := strings.ToLower(strings.TrimSpace(parseTxt(s.Text)["nodeid"]))
+		if !validZeroTierNodeID(id) {
+			log.Println("Warning: No valid nodeid TXT record for", s.Instance)
+			continue
+		}
+		if _, ok := members[id]; ok {
+			continue
+		}
+		ids = append(ids, id)
+		members[id] = zerotierMember{
+			Config: zerotierMemberConfig{Authorized: true},
+			Name:   shortHostname(s.Hostname),
+		}
+	}
+	return ids, members
+}
+
+func writeZeroTier(w io.Writer, discovered []Service, cfg OutputConfig) error {
+	ids, members := zerotierMembers(discovered)
+
+	if "" != cfg.ZeroTierNetwork {
+		client := &http.Client{Timeout: 10 * time.Second}
+		for _, id := range ids {
+			err := postZeroTierMember(client, cfg.ZeroTierNetwork, cfg.ZeroTierToken, id, members[id])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	out, err := json.MarshalIndent(members, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
+
+// Update a network member with the ZeroTier Central API
+func postZeroTierMember(client *http.Client, network string, token string, id string, member zerotierMember) error {
+	body, err := json.Marshal(member)
+	if err != nil {
+		return err
+	}
+
+	url := zerotierCentralURL + "/network/" + network + "/member/" + id
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if "" != token {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("zerotier returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
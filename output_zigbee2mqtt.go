@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Build the MQTT server URL of a broker, mqtts when it announces ssl=true
+func mqttServerURL(s Service) string {
+	scheme := "mqtt"
+	if "true" == parseTxt(s.Text)["ssl"] {
+		scheme = "mqtts"
+	}
+	return scheme + "://" + net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+}
+
+// Write the mqtt section of a Zigbee2MQTT configuration.yaml using the
+// first discovered broker, further brokers are listed as comments
+func writeZigbee2MQTT(w io.Writer, discovered []Service) error {
+	var servers []string
+	seen := make(map[string]bool)
+	for _, s := range discovered {
+		if "_mqtt._tcp" != s.ServiceType {
+			continue
+		}
+		server := mqttServerURL(s)
+		if !seen[server] {
+			seen[server] = true
+			servers = append(servers, server)
+		}
+	}
+	if len(servers) == 0 {
+		_, err := fmt.Fprintln(w, "# No MQTT brokers discovered")
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err := enc.Encode(map[string]interface{}{
+		"mqtt": map[string]string{"server": servers[0]},
+	})
+	if err != nil {
+		return err
+	}
+	err = enc.Close()
+	if err != nil {
+		return err
+	}
+	for _, server := range servers[1:] {
+		_, err := fmt.Fprintf(w, "# server: %s\n", server)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Show browses in flight, results and elapsed time on a terminal
+type progress struct {
+	mu       sync.Mutex
+	out      *os.File
+	active   bool
+	start    time.Time
+	inFlight atomic.Int64
+	results  atomic.Int64
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Update the progress line every 500ms until Stop is called,
+// nothing is shown when out is not a terminal
+func startProgress(out *os.File) *progress {
+	p := &progress{
+		out:   out,
+		start: time.Now(),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if !isTerminal(out) {
+		close(p.done)
+		return p
+	}
+	p.active = true
+
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.mu.Lock()
+				fmt.Fprintf(p.out, "\r%d in flight, %d results, %s elapsed ",
+					p.inFlight.Load(), p.results.Load(), time.Since(p.start).Truncate(time.Second))
+				p.mu.Unlock()
+			case <-p.stop:
+				p.Clear()
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// Count a browse as started, p may be nil
+func (p *progress) begin() {
+	if p != nil {
+		p.inFlight.Add(1)
+	}
+}
+
+// Count a browse as finished with the number of results, p may be nil
+func (p *progress) end(results int) {
+	if p != nil {
+		p.inFlight.Add(-1)
+		p.results.Add(int64(results))
+	}
+}
+
+// Clear the progress line, e.g. before printing results to the same terminal
+func (p *progress) Clear() {
+	if p == nil || !p.active {
+		return
+	}
+	p.mu.Lock()
+	fmt.Fprintf(p.out, "\r%60s\r", "")
+	p.mu.Unlock()
+}
+
+func (p *progress) Stop() {
+	if p == nil {
+		return
+	}
+	select {
+	case <-p.done:
+	default:
+		close(p.stop)
+		<-p.done
+	}
+}
@@ -0,0 +1,4 @@
+// Package mdnspb holds the messages of the protobuf output modes
+package mdnspb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative ../proto/mdns.proto
@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: proto/mdns.proto
+
+package mdnspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Service is a single address of a discovered service instance
+type Service struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceType string            `protobuf:"bytes,1,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`
+	Instance    string            `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"`
+	Hostname    string            `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Address     string            `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Port        int32             `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
+	Txt         []string          `protobuf:"bytes,6,rep,name=txt,proto3" json:"txt,omitempty"`
+	TxtMap      map[string]string `protobuf:"bytes,7,rep,name=txt_map,json=txtMap,proto3" json:"txt_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TxtEncoding string            `protobuf:"bytes,8,opt,name=txt_encoding,json=txtEncoding,proto3" json:"txt_encoding,omitempty"`
+	Cached      bool              `protobuf:"varint,9,opt,name=cached,proto3" json:"cached,omitempty"`
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_mdns_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mdns_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_proto_mdns_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Service) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *Service) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *Service) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Service) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Service) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Service) GetTxt() []string {
+	if x != nil {
+		return x.Txt
+	}
+	return nil
+}
+
+func (x *Service) GetTxtMap() map[string]string {
+	if x != nil {
+		return x.TxtMap
+	}
+	return nil
+}
+
+func (x *Service) GetTxtEncoding() string {
+	if x != nil {
+		return x.TxtEncoding
+	}
+	return ""
+}
+
+func (x *Service) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+// DiscoveryResult holds all services of a discovery run
+type DiscoveryResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Services []*Service `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+}
+
+func (x *DiscoveryResult) Reset() {
+	*x = DiscoveryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_mdns_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiscoveryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveryResult) ProtoMessage() {}
+
+func (x *DiscoveryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mdns_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveryResult.ProtoReflect.Descriptor instead.
+func (*DiscoveryResult) Descriptor() ([]byte, []int) {
+	return file_proto_mdns_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiscoveryResult) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+var File_proto_mdns_proto protoreflect.FileDescriptor
+
+var file_proto_mdns_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x64, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x04, 0x6d, 0x64, 0x6e, 0x73, 0x22, 0xce, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x78, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x74, 0x78, 0x74, 0x12,
+	0x32, 0x0a, 0x07, 0x74, 0x78, 0x74, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x6d, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x54, 0x78, 0x74, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x74, 0x78, 0x74,
+	0x4d, 0x61, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x78, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x78, 0x74, 0x45, 0x6e,
+	0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x1a, 0x39,
+	0x0a, 0x0b, 0x54, 0x78, 0x74, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3c, 0x0a, 0x0f, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x29, 0x0a, 0x08,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6d, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x08, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x62, 0x75, 0x73, 0x73, 0x65, 0x2f, 0x6d, 0x64, 0x6e,
+	0x73, 0x2d, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x3b, 0x6d, 0x64, 0x6e, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_mdns_proto_rawDescOnce sync.Once
+	file_proto_mdns_proto_rawDescData = file_proto_mdns_proto_rawDesc
+)
+
+func file_proto_mdns_proto_rawDescGZIP() []byte {
+	file_proto_mdns_proto_rawDescOnce.Do(func() {
+		file_proto_mdns_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_mdns_proto_rawDescData)
+	})
+	return file_proto_mdns_proto_rawDescData
+}
+
+var file_proto_mdns_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_mdns_proto_goTypes = []any{
+	(*Service)(nil),         // 0: mdns.Service
+	(*DiscoveryResult)(nil), // 1: mdns.DiscoveryResult
+	nil,                     // 2: mdns.Service.TxtMapEntry
+}
+var file_proto_mdns_proto_depIdxs = []int32{
+	2, // 0: mdns.Service.txt_map:type_name -> mdns.Service.TxtMapEntry
+	0, // 1: mdns.DiscoveryResult.services:type_name -> mdns.Service
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_mdns_proto_init() }
+func file_proto_mdns_proto_init() {
+	if File_proto_mdns_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_mdns_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Service); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_mdns_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*DiscoveryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_mdns_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_mdns_proto_goTypes,
+		DependencyIndexes: file_proto_mdns_proto_depIdxs,
+		MessageInfos:      file_proto_mdns_proto_msgTypes,
+	}.Build()
+	File_proto_mdns_proto = out.File
+	file_proto_mdns_proto_rawDesc = nil
+	file_proto_mdns_proto_goTypes = nil
+	file_proto_mdns_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/grandcat/zeroconf"
+)
+
+// Service is a single address of a discovered service instance
+type Service struct {
+	ServiceType string            `json:"service_type"`
+	Instance    string            `json:"instance"`
+	Hostname    string            `json:"hostname"`
+	Address     string            `json:"address"`
+	Port        int               `json:"port"`
+	Text        []string          `json:"txt"`
+	TxtMap      map[string]string `json:"txt_map"`
+	TxtEncoding string            `json:"txt_encoding,omitempty"`
+	Cached      bool              `json:"_cached,omitempty"`
+}
+
+// Create one Service per address of a resolved entry
+func newServices(serviceType string, entry *zeroconf.ServiceEntry) []Service {
+	var found []Service
+	addrs := append(append([]net.IP{}, entry.AddrIPv4...), entry.AddrIPv6...)
+	for _, addr := range addrs {
+		found = append(found, Service{
+			ServiceType: serviceType,
+			Instance:    entry.Instance,
+			Hostname:    entry.HostName,
+			Address:     addr.String(),
+			Port:        entry.Port,
+			Text:        entry.Text,
+			TxtMap:      parseTxt(entry.Text),
+		})
+	}
+	return found
+}
+
+// Build a key identifying a service address,
+// e.g. "_http._tcp/host.local/192.168.1.10:80"
+func buildKey(s Service) string {
+	return s.ServiceType + "/" + strings.TrimSuffix(s.Hostname, ".") + "/" +
+		net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+}
+
+// Keep services with an address of the given IP version,
+// an empty version keeps all services
+func filterIPVersion(discovered []Service, version string) []Service {
+	if "" == version {
+		return discovered
+	}
+	filtered := make([]Service, 0, len(discovered))
+	for _, s := range discovered {
+		ip := net.ParseIP(s.Address)
+		if ip == nil {
+			continue
+		}
+		if (ip.To4() != nil) == ("4" == version) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// Value replacing masked fields
+const maskedValue = "***"
+
+// Replace the values of the given fields with maskedValue,
+// a masked port becomes 0 and masked TXT records keep their keys
+func maskFields(discovered []Service, fields []string) []Service {
+	if len(fields) == 0 {
+		return discovered
+	}
+	masked := make([]Service, 0, len(discovered))
+	for _, s := range discovered {
+		for _, field := range fields {
+			switch field {
+			case "service_type":
+				s.ServiceType = maskedValue
+			case "instance":
+				s.Instance = maskedValue
+			case "hostname":
+				s.Hostname = maskedValue
+			case "address":
+				s.Address = maskedValue
+			case "port":
+				s.Port = 0
+			case "txt":
+				s.Text = []string{maskedValue}
+				txt := make(map[string]string, len(s.TxtMap))
+				for key := range s.TxtMap {
+					txt[key] = maskedValue
+				}
+				s.TxtMap = txt
+			}
+		}
+		masked = append(masked, s)
+	}
+	return masked
+}
+
+// Encode TXT records and TXT map values with Base64 so binary data
+// survives JSON encoding, TXT map keys stay readable
+func encodeTxtBase64(discovered []Service) []Service {
+	encoded := make([]Service, 0, len(discovered))
+	for _, s := range discovered {
+		if s.Text != nil {
+			text := make([]string, len(s.Text))
+			for i, record := range s.Text {
+				text[i] = base64.StdEncoding.EncodeToString([]byte(record))
+			}
+			s.Text = text
+		}
+		if s.TxtMap != nil {
+			txt := make(map[string]string, len(s.TxtMap))
+			for key, value := range s.TxtMap {
+				txt[key] = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+			s.TxtMap = txt
+		}
+		s.TxtEncoding = "base64"
+		encoded = append(encoded, s)
+	}
+	return encoded
+}
+
+// Strip the trailing dot and the .local domain from a hostname
+func shortHostname(hostname string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(hostname, "."), ".local")
+}
+
+// Split TXT records of the form key=value into a map,
+// records without a value map to an empty string
+func parseTxt(records []string) map[string]string {
+	txt := make(map[string]string, len(records))
+	for _, record := range records {
+		key, value, _ := strings.Cut(record, "=")
+		if "" == key {
+			continue
+		}
+		if _, ok := txt[key]; !ok {
+			txt[key] = value
+		}
+	}
+	return txt
+}
+
+// Check that a service type has the form _<name>._tcp or _<name>._udp
+func validateServiceType(name string) error {
+	service, proto, ok := strings.Cut(name, ".")
+	if !ok || len(service) < 2 || !strings.HasPrefix(service, "_") {
+		return fmt.Errorf("%q: expected _<name>._tcp or _<name>._udp", name)
+	}
+	if "_tcp" != proto && "_udp" != proto {
+		return fmt.Errorf("%q: protocol must be _tcp or _udp", name)
+	}
+	return nil
+}